@@ -0,0 +1,44 @@
+package verifier
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Aggregator merges verifiers of several labeled stages, e.g. of multi-stage ETL pipeline, into one result.
+// Zero value is ready to use. Aggregator is safe for concurrent use.
+type Aggregator struct {
+	mu       sync.Mutex
+	failures []error
+}
+
+// Add adds verifier of stage with specified label and marks it as checked.
+// Failure of the stage is prefixed with its label.
+func (a *Aggregator) Add(label string, v *Verify) {
+	err := v.GetError()
+	if err == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.failures = append(a.failures, &prefixedError{prefix: label, err: err})
+}
+
+// Result creates verifier that tracks verification state, same as New, holding failures of all added stages.
+// If several stages failed, verification error contains all of their labeled messages in order of addition.
+func (a *Aggregator) Result() *Verify {
+	v := &Verify{
+		creationStack: captureCreationStack(),
+	}
+	runtime.SetFinalizer(v, printWarningOnUncheckedVerification)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	switch len(a.failures) {
+	case 0:
+	case 1:
+		v.err = a.failures[0]
+	default:
+		v.err = multiError(append([]error(nil), a.failures...))
+	}
+	return v
+}
@@ -0,0 +1,53 @@
+package verifier_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+)
+
+func TestAggregator(t *testing.T) {
+	extract := verifier.New().That(true, "source should be reachable")
+	transform := verifier.New().That(false, "amount should be positive")
+	load := verifier.New().That(false, "table should exist")
+
+	aggregator := &verifier.Aggregator{}
+	aggregator.Add("extract", extract)
+	aggregator.Add("transform", transform)
+	aggregator.Add("load", load)
+
+	result := aggregator.Result()
+	if result.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if result.GetError().Error() != "transform: amount should be positive; load: table should exist" {
+		t.Errorf("unexpected error message: %s", result.GetError())
+	}
+	if !errors.Is(result.GetError(), verifier.ErrVerificationFailed) {
+		t.Errorf("error should wrap ErrVerificationFailed: %s", result.GetError())
+	}
+}
+
+func TestAggregator_single_failure(t *testing.T) {
+	aggregator := &verifier.Aggregator{}
+	aggregator.Add("extract", verifier.New().That(true, "source should be reachable"))
+	aggregator.Add("load", verifier.New().That(false, "table should exist"))
+
+	result := aggregator.Result()
+	if result.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if result.GetError().Error() != "load: table should exist" {
+		t.Errorf("unexpected error message: %s", result.GetError())
+	}
+}
+
+func TestAggregator_empty(t *testing.T) {
+	aggregator := &verifier.Aggregator{}
+	aggregator.Add("extract", verifier.New().That(true, "source should be reachable"))
+	result := aggregator.Result()
+	if result.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", result.GetError())
+	}
+}
@@ -0,0 +1,59 @@
+package verifier
+
+import "reflect"
+
+// CallEquals invokes function fn with callArgs using reflection and verifies
+// that values it returns are deeply equal to expected, e.g. to declare table-driven tests.
+// Nil in callArgs is passed as zero value of corresponding parameter.
+// On failure, message is followed by the index of the first differing result and both values,
+// or by description of arity or type mismatch between fn, callArgs and expected.
+// After the first failed verification fn won't be called.
+func CallEquals(
+	v *Verify, fn interface{}, callArgs []interface{}, expected []interface{}, message string, args ...interface{},
+) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		fnValue := reflect.ValueOf(fn)
+		if fnValue.Kind() != reflect.Func {
+			return v.errorfWithDetail(message, args, "%T is not a function", fn)
+		}
+		fnType := fnValue.Type()
+		if fnType.IsVariadic() && len(callArgs) < fnType.NumIn()-1 {
+			return v.errorfWithDetail(
+				message, args, "%T expects at least %d arguments, got %d", fn, fnType.NumIn()-1, len(callArgs),
+			)
+		}
+		if !fnType.IsVariadic() && len(callArgs) != fnType.NumIn() {
+			return v.errorfWithDetail(message, args, "%T expects %d arguments, got %d", fn, fnType.NumIn(), len(callArgs))
+		}
+		if len(expected) != fnType.NumOut() {
+			return v.errorfWithDetail(message, args, "%T returns %d values, expected %d", fn, fnType.NumOut(), len(expected))
+		}
+		in := make([]reflect.Value, 0, len(callArgs))
+		for i, arg := range callArgs {
+			paramType := parameterType(fnType, i)
+			if arg == nil {
+				in = append(in, reflect.Zero(paramType))
+				continue
+			}
+			argValue := reflect.ValueOf(arg)
+			if !argValue.Type().AssignableTo(paramType) {
+				return v.errorfWithDetail(message, args, "argument %d: %T is not assignable to %v", i, arg, paramType)
+			}
+			in = append(in, argValue)
+		}
+		for i, result := range fnValue.Call(in) {
+			if !reflect.DeepEqual(result.Interface(), expected[i]) {
+				return v.errorfWithDetail(message, args, "result %d: %v != %v", i, result.Interface(), expected[i])
+			}
+		}
+		return nil
+	})
+}
+
+// parameterType returns type of i-th argument of function fnType, taking variadic parameter into account.
+func parameterType(fnType reflect.Type, i int) reflect.Type {
+	if fnType.IsVariadic() && i >= fnType.NumIn()-1 {
+		return fnType.In(fnType.NumIn() - 1).Elem()
+	}
+	return fnType.In(i)
+}
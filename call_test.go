@@ -0,0 +1,70 @@
+package verifier_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+)
+
+func TestCallEquals(t *testing.T) {
+	sum := func(base int, values ...int) int {
+		for _, value := range values {
+			base += value
+		}
+		return base
+	}
+	verify := verifier.New()
+	verifier.CallEquals(verify, strconv.Atoi, []interface{}{"42"}, []interface{}{42, nil}, "unexpected result")
+	verifier.CallEquals(verify, strings.Repeat, []interface{}{"ab", 2}, []interface{}{"abab"}, "unexpected result")
+	verifier.CallEquals(verify, sum, []interface{}{1, 2, 3}, []interface{}{6}, "unexpected result")
+	verifier.CallEquals(verify, sum, []interface{}{1}, []interface{}{1}, "unexpected result")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.CallEquals(verify, strings.Repeat, []interface{}{"ab", 3}, []interface{}{"abab"}, "unexpected %s", "result")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected result: result 0: ababab != abab" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestCallEquals_mismatch(t *testing.T) {
+	testCases := []struct {
+		fn       interface{}
+		args     []interface{}
+		expected []interface{}
+		message  string
+	}{
+		{
+			fn: "Repeat", args: nil, expected: nil,
+			message: "unexpected result: string is not a function",
+		},
+		{
+			fn: strings.Repeat, args: []interface{}{"ab"}, expected: []interface{}{"abab"},
+			message: "unexpected result: func(string, int) string expects 2 arguments, got 1",
+		},
+		{
+			fn: strings.Repeat, args: []interface{}{"ab", 2}, expected: []interface{}{"abab", nil},
+			message: "unexpected result: func(string, int) string returns 1 values, expected 2",
+		},
+		{
+			fn: strings.Repeat, args: []interface{}{"ab", "2"}, expected: []interface{}{"abab"},
+			message: "unexpected result: argument 1: string is not assignable to int",
+		},
+	}
+	for _, testCase := range testCases {
+		verify := verifier.New()
+		verifier.CallEquals(verify, testCase.fn, testCase.args, testCase.expected, "unexpected result")
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %s", testCase.message)
+		}
+		if verify.GetError().Error() != testCase.message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package verifier
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Subset verifies that every element of subset is present in superset.
+// On failure, message is followed by the first missing element.
+// Empty subset always passes.
+func Subset[T comparable](v *Verify, subset, superset []T, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		present := make(map[T]struct{}, len(superset))
+		for _, e := range superset {
+			present[e] = struct{}{}
+		}
+		for _, e := range subset {
+			if _, ok := present[e]; !ok {
+				return v.errorfWithDetail(message, args, "%v is missing", e)
+			}
+		}
+		return nil
+	})
+}
+
+// IsPermutationOf verifies that actual is a reordering of expected:
+// both contain the same elements, each the same number of times.
+// On failure, message is followed by the first element, which counts differ.
+func IsPermutationOf[T comparable](v *Verify, actual, expected []T, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		actualCounts := make(map[T]int, len(actual))
+		for _, e := range actual {
+			actualCounts[e]++
+		}
+		expectedCounts := make(map[T]int, len(expected))
+		for _, e := range expected {
+			expectedCounts[e]++
+		}
+		for _, elements := range [][]T{expected, actual} {
+			for _, e := range elements {
+				if actualCounts[e] != expectedCounts[e] {
+					return v.errorfWithDetail(message, args, "%v occurs %d times, expected %d", e, actualCounts[e], expectedCounts[e])
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// AllValues verifies that every value of map m satisfies predicate.
+// On failure, message is followed by the key of offending value.
+// If there are several of them, keys are sorted and the first one is reported,
+// so message is deterministic. Nil map always passes.
+func AllValues[K comparable, V any](v *Verify, m map[K]V, pred func(V) bool, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		var failedKeys []K
+		for key, value := range m {
+			if !pred(value) {
+				failedKeys = append(failedKeys, key)
+			}
+		}
+		if len(failedKeys) == 0 {
+			return nil
+		}
+		sortKeys(failedKeys)
+		return v.errorfWithDetail(message, args, "value of %v is invalid", failedKeys[0])
+	})
+}
+
+// ContainsEntries verifies that map m contains all key/value pairs of expected, m may have other entries as well.
+// On failure, message is followed by the first, in order of keys, missing or mismatched entry.
+func ContainsEntries[K, V comparable](v *Verify, m map[K]V, expected map[K]V, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		keys := make([]K, 0, len(expected))
+		for key := range expected {
+			keys = append(keys, key)
+		}
+		sortKeys(keys)
+		for _, key := range keys {
+			actual, ok := m[key]
+			if !ok {
+				return v.errorfWithDetail(message, args, "key %v is absent", key)
+			}
+			if actual != expected[key] {
+				return v.errorfWithDetail(message, args, "value of %v is %v, expected %v", key, actual, expected[key])
+			}
+		}
+		return nil
+	})
+}
+
+// sortKeys sorts map keys by value if they are numbers or strings of the same kind,
+// or by their string representation otherwise, e.g. for interface keys of different dynamic types.
+func sortKeys[K comparable](keys []K) {
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := reflect.ValueOf(keys[i]), reflect.ValueOf(keys[j])
+		if a.Kind() != b.Kind() {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		}
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		default:
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		}
+	})
+}
+
+// LenParity verifies that length of obj is even if wantEven is true, or odd otherwise.
+// Length is obtained using reflection, so obj can be array, slice, map, string or channel,
+// nil values of these types have zero length.
+// On failure, message is followed by actual length.
+func LenParity(v *Verify, obj interface{}, wantEven bool, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		length, ok := lengthOf(obj)
+		if !ok {
+			return v.errorfWithDetail(message, args, "can't get length of %T", obj)
+		}
+		if (length%2 == 0) != wantEven {
+			return v.errorfWithDetail(message, args, "length is %d", length)
+		}
+		return nil
+	})
+}
+
+// NotNilNotEmpty verifies that collection obj is neither nil nor empty, distinguishing these cases:
+// on failure, message is followed by "must not be nil" for nil slices and maps
+// or by "must not be empty" for empty ones.
+// Length is obtained using reflection, so obj can be array, slice, map, string or channel.
+func (v *Verify) NotNilNotEmpty(obj interface{}, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		value := reflect.ValueOf(obj)
+		if !value.IsValid() {
+			return v.errorfWithDetail(message, args, "must not be nil")
+		}
+		switch value.Kind() {
+		case reflect.Chan, reflect.Map, reflect.Slice:
+			if value.IsNil() {
+				return v.errorfWithDetail(message, args, "must not be nil")
+			}
+		}
+		length, ok := lengthOf(obj)
+		if !ok {
+			return v.errorfWithDetail(message, args, "can't get length of %T", obj)
+		}
+		if length == 0 {
+			return v.errorfWithDetail(message, args, "must not be empty")
+		}
+		return nil
+	})
+}
+
+// SameLen verifies that collections a and b have the same length.
+// Length is obtained using reflection, so a and b can be arrays, slices, maps, strings or channels.
+// On failure, message is followed by both lengths.
+func SameLen(v *Verify, a, b interface{}, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		aLength, ok := lengthOf(a)
+		if !ok {
+			return v.errorfWithDetail(message, args, "can't get length of %T", a)
+		}
+		bLength, ok := lengthOf(b)
+		if !ok {
+			return v.errorfWithDetail(message, args, "can't get length of %T", b)
+		}
+		if aLength != bLength {
+			return v.errorfWithDetail(message, args, "lengths are %d and %d", aLength, bLength)
+		}
+		return nil
+	})
+}
+
+// CountEquals verifies that collection items contains exactly expected number of elements,
+// e.g. number of results returned on a page.
+// Length is obtained using reflection, so items can be array, slice, map, string or channel.
+// On failure, message is followed by actual count.
+func CountEquals(v *Verify, items interface{}, expected int, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		count, ok := lengthOf(items)
+		if !ok {
+			return v.errorfWithDetail(message, args, "can't get length of %T", items)
+		}
+		if count != expected {
+			return v.errorfWithDetail(message, args, "count is %d, expected %d", count, expected)
+		}
+		return nil
+	})
+}
+
+func lengthOf(obj interface{}) (int, bool) {
+	value := reflect.ValueOf(obj)
+	switch value.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len(), true
+	default:
+		return 0, false
+	}
+}
+
+// ChannelCap verifies that buffer capacity of channel ch is equal to expected.
+// On failure, message is followed by actual capacity.
+// Non-channel argument is reported as failure.
+func (v *Verify) ChannelCap(ch interface{}, expected int, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		value := reflect.ValueOf(ch)
+		if value.Kind() != reflect.Chan {
+			return v.errorfWithDetail(message, args, "%T is not a channel", ch)
+		}
+		if value.Cap() != expected {
+			return v.errorfWithDetail(message, args, "capacity is %d", value.Cap())
+		}
+		return nil
+	})
+}
+
+// ChannelLen verifies that number of elements queued in channel ch is equal to expected.
+// On failure, message is followed by actual length.
+// Non-channel argument is reported as failure.
+func (v *Verify) ChannelLen(ch interface{}, expected int, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		value := reflect.ValueOf(ch)
+		if value.Kind() != reflect.Chan {
+			return v.errorfWithDetail(message, args, "%T is not a channel", ch)
+		}
+		if value.Len() != expected {
+			return v.errorfWithDetail(message, args, "length is %d", value.Len())
+		}
+		return nil
+	})
+}
+
+// ChannelsEqual verifies that channels a and b deliver the same sequence of elements.
+// Both channels are drained until closed, so they must be closed by their producers.
+// On failure, message is followed by the position of the first divergence or by both lengths.
+func ChannelsEqual[T comparable](v *Verify, a, b <-chan T, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		var aItems, bItems []T
+		for item := range a {
+			aItems = append(aItems, item)
+		}
+		for item := range b {
+			bItems = append(bItems, item)
+		}
+		for i := 0; i < len(aItems) && i < len(bItems); i++ {
+			if aItems[i] != bItems[i] {
+				return v.errorfWithDetail(message, args, "element %d differs: %v != %v", i, aItems[i], bItems[i])
+			}
+		}
+		if len(aItems) != len(bItems) {
+			return v.errorfWithDetail(message, args, "lengths are %d and %d", len(aItems), len(bItems))
+		}
+		return nil
+	})
+}
+
+// StrictlyIncreasing verifies that each element of slice s is strictly greater than the previous one,
+// so equal adjacent elements are reported as failure, e.g. for monotonic sequence IDs.
+// On failure, message is followed by the index of offending element and both compared elements.
+func StrictlyIncreasing[T Ordered](v *Verify, s []T, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		for i := 1; i < len(s); i++ {
+			if !(s[i] > s[i-1]) {
+				return v.errorfWithDetail(message, args, "element %d (%v) is not greater than %v", i, s[i], s[i-1])
+			}
+		}
+		return nil
+	})
+}
+
+// NoKeyCollisions verifies that no key appears in more than one of maps, e.g. before merging them.
+// Nil maps are treated as empty.
+// On failure, error message contains colliding key and indexes of maps where it appears.
+func NoKeyCollisions[K comparable, V any](v *Verify, maps ...map[K]V) *Verify {
+	return v.check("maps should have disjoint keys", nil, func(v *Verify) error {
+		owners := make(map[K]int)
+		for i, m := range maps {
+			for key := range m {
+				if owner, ok := owners[key]; ok {
+					return v.errorf("key %v appears in maps #%d and #%d", key, owner, i)
+				}
+				owners[key] = i
+			}
+		}
+		return nil
+	})
+}
+
+// UniqueBy verifies that no two elements of slice s have the same key, produced by keyFn,
+// e.g. that objects have unique IDs, even if objects themselves aren't comparable.
+// On failure, message is followed by the first duplicate key and indexes of elements having it.
+func UniqueBy[T any, K comparable](v *Verify, s []T, keyFn func(T) K, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		seen := make(map[K]int, len(s))
+		for i, item := range s {
+			key := keyFn(item)
+			if first, ok := seen[key]; ok {
+				return v.errorfWithDetail(message, args, "key %v is duplicated at %d and %d", key, first, i)
+			}
+			seen[key] = i
+		}
+		return nil
+	})
+}
@@ -0,0 +1,31 @@
+//go:build go1.20
+
+package verifier_test
+
+import (
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+)
+
+// Maps with interface keys satisfy comparable constraint only since go1.20.
+func TestAllValues_interface_keys(t *testing.T) {
+	positive := func(timeout int) bool { return timeout > 0 }
+	verify := verifier.New()
+	verifier.AllValues(verify, map[interface{}]int{1: -1, "a": -1, nil: -1, 2.5: 1}, positive, "invalid timeout")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "invalid timeout: value of 1 is invalid" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.ContainsEntries(verify, map[interface{}]int{1: 1}, map[interface{}]int{1: 1, "a": 2, nil: 3}, "unexpected entries")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected entries: key <nil> is absent" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
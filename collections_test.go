@@ -0,0 +1,389 @@
+package verifier_test
+
+import (
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+)
+
+func TestSubset(t *testing.T) {
+	allowed := []string{"id", "name", "email"}
+
+	verify := verifier.New()
+	verifier.Subset(verify, []string{"name", "id"}, allowed, "unknown fields requested")
+	verifier.Subset(verify, []string{"email", "name", "id"}, allowed, "unknown fields requested")
+	verifier.Subset(verify, []string{}, allowed, "unknown fields requested")
+	verifier.Subset[string](verify, nil, nil, "unknown fields requested")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.Subset(verify, []string{"id", "password", "secret"}, allowed, "unknown fields in %s", "request")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unknown fields in request: password is missing" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestLenParity(t *testing.T) {
+	var nilSlice []string
+	verify := verifier.New()
+	verifier.LenParity(verify, []string{"key1", "value1", "key2", "value2"}, true, "key/value pairs expected")
+	verifier.LenParity(verify, nilSlice, true, "key/value pairs expected")
+	verifier.LenParity(verify, [3]int{}, false, "odd length expected")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.LenParity(verify, []string{"key1", "value1", "key2"}, true, "key/value pairs expected")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "key/value pairs expected: length is 3" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.LenParity(verify, 42, true, "key/value pairs expected")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "key/value pairs expected: can't get length of int" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestIsPermutationOf(t *testing.T) {
+	verify := verifier.New()
+	verifier.IsPermutationOf(verify, []int{3, 1, 2, 1}, []int{1, 1, 2, 3}, "not a permutation")
+	verifier.IsPermutationOf(verify, []int{}, nil, "not a permutation")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.IsPermutationOf(verify, []int{3, 1, 2, 2}, []int{1, 1, 2, 3}, "not a %s", "permutation")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "not a permutation: 1 occurs 1 times, expected 2" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.IsPermutationOf(verify, []string{"b", "x", "a"}, []string{"a", "b", "c"}, "not a permutation")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "not a permutation: c occurs 0 times, expected 1" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestVerifier_ChannelCap(t *testing.T) {
+	jobs := make(chan int, 8)
+	var results <-chan string = make(chan string)
+	verify := verifier.New()
+	verify.ChannelCap(jobs, 8, "unexpected jobs queue size")
+	verify.ChannelCap(results, 0, "results should be unbuffered")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verify.ChannelCap(jobs, 16, "unexpected %s queue size", "jobs")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected jobs queue size: capacity is 8" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verify.ChannelCap([]int{}, 0, "unexpected jobs queue size")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected jobs queue size: []int is not a channel" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestVerifier_ChannelLen(t *testing.T) {
+	jobs := make(chan int, 8)
+	jobs <- 1
+	jobs <- 2
+	verify := verifier.New()
+	verify.ChannelLen(jobs, 2, "unexpected number of queued jobs")
+	verify.ChannelLen(make(chan int), 0, "unexpected number of queued jobs")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verify.ChannelLen(jobs, 0, "unexpected number of queued jobs")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected number of queued jobs: length is 2" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verify.ChannelLen(nil, 0, "unexpected number of queued jobs")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected number of queued jobs: <nil> is not a channel" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestSameLen(t *testing.T) {
+	keys := []string{"id", "name"}
+	verify := verifier.New()
+	verifier.SameLen(verify, keys, []interface{}{1, "John"}, "keys and values should be aligned")
+	verifier.SameLen(verify, map[string]int{"a": 1}, [1]bool{}, "keys and values should be aligned")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.SameLen(verify, keys, []interface{}{1}, "%s and values should be aligned", "keys")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "keys and values should be aligned: lengths are 2 and 1" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.SameLen(verify, keys, 2, "keys and values should be aligned")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "keys and values should be aligned: can't get length of int" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestCountEquals(t *testing.T) {
+	page := []string{"order-1", "order-2", "order-3"}
+	verify := verifier.New()
+	verifier.CountEquals(verify, page, 3, "page should be full")
+	verifier.CountEquals(verify, map[string]int{}, 0, "page should be empty")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.CountEquals(verify, page[:2], 3, "page %d should be full", 2)
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "page 2 should be full: count is 2, expected 3" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.CountEquals(verify, 3, 3, "page should be full")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "page should be full: can't get length of int" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestContainsEntries(t *testing.T) {
+	headers := map[string]string{"Content-Type": "application/json", "Cache-Control": "no-cache", "X-Request-Id": "42"}
+	verify := verifier.New()
+	verifier.ContainsEntries(verify, headers, map[string]string{"Content-Type": "application/json"}, "unexpected headers")
+	verifier.ContainsEntries(verify, headers, nil, "unexpected headers")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := map[string]map[string]string{
+		"unexpected headers: key ETag is absent": {"Content-Type": "application/json", "ETag": "v1"},
+		"unexpected headers: value of Cache-Control is no-cache, expected max-age=60": {
+			"Cache-Control": "max-age=60", "Content-Type": "application/json",
+		},
+	}
+	for message, expected := range testCases {
+		verify := verifier.New()
+		verifier.ContainsEntries(verify, headers, expected, "unexpected %s", "headers")
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %v", expected)
+		}
+		if verify.GetError().Error() != message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
+
+func TestAllValues(t *testing.T) {
+	positive := func(timeout int) bool { return timeout > 0 }
+	verify := verifier.New()
+	verifier.AllValues(verify, map[string]int{"read": 5, "write": 10}, positive, "invalid timeout")
+	verifier.AllValues(verify, map[string]int(nil), positive, "invalid timeout")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.AllValues(verify, map[string]int{"read": 5, "write": 0, "idle": -1}, positive, "invalid %s", "timeout")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "invalid timeout: value of idle is invalid" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.AllValues(verify, map[int]string{10: "", 9: "", 1: "ok"}, func(s string) bool { return s != "" }, "empty name")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "empty name: value of 9 is invalid" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestVerifier_NotNilNotEmpty(t *testing.T) {
+	verify := verifier.New()
+	verify.NotNilNotEmpty([]string{"admin"}, "roles")
+	verify.NotNilNotEmpty(map[string]int{"a": 1}, "limits")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	var nilRoles []string
+	testCases := []struct {
+		name    string
+		obj     interface{}
+		message string
+	}{
+		{"nil slice", nilRoles, "roles: must not be nil"},
+		{"untyped nil", nil, "roles: must not be nil"},
+		{"empty slice", []string{}, "roles: must not be empty"},
+		{"empty map", map[string]int{}, "roles: must not be empty"},
+		{"not a collection", 42, "roles: can't get length of int"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			verify := verifier.New()
+			verify.NotNilNotEmpty(testCase.obj, "%s", "roles")
+			if verify.GetError() == nil {
+				t.Fatal("verifier should be filled")
+			}
+			if verify.GetError().Error() != testCase.message {
+				t.Errorf("unexpected error message: %s", verify.GetError())
+			}
+		})
+	}
+}
+
+func TestChannelsEqual(t *testing.T) {
+	stream := func(events ...string) <-chan string {
+		ch := make(chan string)
+		go func() {
+			defer close(ch)
+			for _, event := range events {
+				ch <- event
+			}
+		}()
+		return ch
+	}
+
+	verify := verifier.New()
+	verifier.ChannelsEqual(verify, stream("created", "paid"), stream("created", "paid"), "unexpected events")
+	verifier.ChannelsEqual(verify, stream(), stream(), "unexpected events")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := []struct {
+		actual   <-chan string
+		expected <-chan string
+		message  string
+	}{
+		{stream("created", "refunded"), stream("created", "paid"), "unexpected events: element 1 differs: refunded != paid"},
+		{stream("created"), stream("created", "paid"), "unexpected events: lengths are 1 and 2"},
+	}
+	for _, testCase := range testCases {
+		verify := verifier.New()
+		verifier.ChannelsEqual(verify, testCase.actual, testCase.expected, "unexpected %s", "events")
+		if verify.GetError() == nil {
+			t.Fatal("verifier should be filled")
+		}
+		if verify.GetError().Error() != testCase.message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
+
+func TestStrictlyIncreasing(t *testing.T) {
+	verify := verifier.New()
+	verifier.StrictlyIncreasing(verify, []int64{1, 2, 5, 9}, "sequence IDs should be monotonic")
+	verifier.StrictlyIncreasing(verify, []string{"a", "b"}, "sequence IDs should be monotonic")
+	verifier.StrictlyIncreasing(verify, []int64(nil), "sequence IDs should be monotonic")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := map[string][]int64{
+		"sequence IDs should be monotonic: element 2 (2) is not greater than 2": {1, 2, 2, 3},
+		"sequence IDs should be monotonic: element 1 (2) is not greater than 3": {3, 2, 1},
+	}
+	for message, ids := range testCases {
+		verify := verifier.New()
+		verifier.StrictlyIncreasing(verify, ids, "sequence IDs should be %s", "monotonic")
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %v", ids)
+		}
+		if verify.GetError().Error() != message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
+
+func TestNoKeyCollisions(t *testing.T) {
+	defaults := map[string]string{"host": "localhost", "port": "8080"}
+	overrides := map[string]string{"timeout": "5s"}
+	verify := verifier.New()
+	verifier.NoKeyCollisions(verify, defaults, overrides)
+	verifier.NoKeyCollisions(verify, defaults, nil, overrides, nil)
+	verifier.NoKeyCollisions[string, string](verify)
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.NoKeyCollisions(verify, defaults, nil, overrides, map[string]string{"port": "9090"})
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "key port appears in maps #0 and #3" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestUniqueBy(t *testing.T) {
+	type user struct {
+		ID    int
+		Roles []string
+	}
+	byID := func(u user) int { return u.ID }
+
+	verify := verifier.New()
+	verifier.UniqueBy(verify, []user{{ID: 1}, {ID: 2, Roles: []string{"admin"}}}, byID, "user IDs should be unique")
+	verifier.UniqueBy(verify, []user(nil), byID, "user IDs should be unique")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	users := []user{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 2, Roles: []string{"admin"}}, {ID: 1}}
+	verifier.UniqueBy(verify, users, byID, "user %s should be unique", "IDs")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "user IDs should be unique: key 2 is duplicated at 1 and 3" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
@@ -0,0 +1,82 @@
+package verifier
+
+// NamedCheck is a named group of checks, performed on verifier by Fn.
+type NamedCheck struct {
+	Name string
+	Fn   func(*Verify)
+}
+
+// RunChecks performs named checks in order, prefixing failure message with the name of failed check.
+// It allows to assemble verification from dynamically selected rules.
+// After the first failed verification other checks won't run.
+func (v *Verify) RunChecks(checks ...NamedCheck) *Verify {
+	vObj := v
+	if v == nil {
+		vObj = &Verify{}
+	}
+	for _, c := range checks {
+		if vObj.err != nil {
+			break
+		}
+		c.Fn(vObj)
+		if vObj.err != nil {
+			vObj.err = &prefixedError{prefix: c.Name, err: vObj.err}
+		}
+	}
+	return vObj
+}
+
+// Rules evaluates rules in order, storing the first returned error as verification failure.
+// It allows to reuse plain `func() error` validators. Rules returning nil pass.
+// After the first failed verification other rules won't be evaluated.
+func (v *Verify) Rules(rules ...func() error) *Verify {
+	for i, rule := range rules {
+		v = v.check("rule #%d", []interface{}{i + 1}, func(v *Verify) error {
+			return rule()
+		})
+	}
+	return v
+}
+
+// Try runs fn, recovering from its panic and storing it as verification failure.
+// If fn panics with an error, that error is stored as is, otherwise panic value is formatted into failure message.
+// After the first failed verification fn won't run.
+func (v *Verify) Try(fn func()) *Verify {
+	return v.check("try", nil, func(v *Verify) (err error) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recoveredErr, ok := recovered.(error); ok {
+				err = recoveredErr
+				return
+			}
+			err = v.errorf("panic: %v", recovered)
+		}()
+		fn()
+		return nil
+	})
+}
+
+// Once runs check only the first time key is seen by this verifier, caching its result for subsequent calls.
+// Use it for expensive invariants of immutable data verified in loops.
+// Check returns whether it passed and failure message if not, failure message is prefixed with key.
+// After the first failed verification check won't run.
+func (v *Verify) Once(key string, check func() (bool, string)) *Verify {
+	return v.check("%s", []interface{}{key}, func(v *Verify) error {
+		result, seen := v.onceResults[key]
+		if !seen {
+			passed, message := check()
+			result = CheckResult{Message: message, Passed: passed}
+			if v.onceResults == nil {
+				v.onceResults = make(map[string]CheckResult)
+			}
+			v.onceResults[key] = result
+		}
+		if result.Passed {
+			return nil
+		}
+		return v.errorf("%s: %s", key, result.Message)
+	})
+}
@@ -0,0 +1,154 @@
+package verifier_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+)
+
+func TestVerifier_RunChecks(t *testing.T) {
+	var order []string
+	check := func(name string, positiveCondition bool) verifier.NamedCheck {
+		return verifier.NamedCheck{
+			Name: name,
+			Fn: func(v *verifier.Verify) {
+				order = append(order, name)
+				v.That(positiveCondition, "%s check failed", name)
+			},
+		}
+	}
+
+	verify := verifier.New()
+	verify.RunChecks(check("first", true), check("second", true))
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verify.RunChecks(check("third", true), check("fourth", false), check("fifth", false))
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "fourth: fourth check failed" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+	if !errors.Is(verify.GetError(), verifier.ErrVerificationFailed) {
+		t.Errorf("prefixed error should wrap original error")
+	}
+	expectedOrder := []string{"first", "second", "third", "fourth"}
+	if len(order) != len(expectedOrder) {
+		t.Fatalf("unexpected checks order: %v", order)
+	}
+	for i := range expectedOrder {
+		if order[i] != expectedOrder[i] {
+			t.Fatalf("unexpected checks order: %v", order)
+		}
+	}
+}
+
+func TestVerifier_Rules(t *testing.T) {
+	calls := 0
+	pass := func() error {
+		calls++
+		return nil
+	}
+	expectedErr := errors.New("insufficient funds")
+	fail := func() error {
+		calls++
+		return expectedErr
+	}
+
+	verify := verifier.New()
+	verify.Rules(pass, pass)
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verify.Rules(pass, fail, pass, fail)
+	if verify.GetError() != expectedErr {
+		t.Errorf("unexpected error: %v", verify.GetError())
+	}
+	if calls != 4 {
+		t.Errorf("unexpected evaluations happened: %d", calls)
+	}
+}
+
+func TestVerifier_Try(t *testing.T) {
+	verify := verifier.New()
+	verify.Try(func() {})
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verify.Try(func() {
+		panic("index out of range")
+	})
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "panic: index out of range" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	calls := 0
+	verify.Try(func() { calls++ })
+	if calls != 0 {
+		t.Errorf("unexpected evaluations happened")
+	}
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+
+	expectedErr := errors.New("connection refused")
+	verify = verifier.New()
+	verify.Try(func() {
+		panic(expectedErr)
+	})
+	if verify.GetError() != expectedErr {
+		t.Errorf("unexpected error: %v", verify.GetError())
+	}
+}
+
+func TestVerifier_Once(t *testing.T) {
+	calls := map[string]int{}
+	schemaLoaded := func() (bool, string) {
+		calls["schema"]++
+		return true, ""
+	}
+	quotaAvailable := func() (bool, string) {
+		calls["quota"]++
+		return false, "quota exhausted"
+	}
+
+	verify := verifier.New()
+	for i := 0; i < 3; i++ {
+		verify.Once("schema", schemaLoaded)
+	}
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+	if calls["schema"] != 1 {
+		t.Errorf("check should run once, got %d calls", calls["schema"])
+	}
+
+	for i := 0; i < 3; i++ {
+		verify.Once("quota", quotaAvailable)
+	}
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "quota: quota exhausted" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+	if calls["quota"] != 1 {
+		t.Errorf("check should run once, got %d calls", calls["quota"])
+	}
+
+	other := verifier.New().Once("schema", schemaLoaded)
+	if other.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", other.GetError())
+	}
+	if calls["schema"] != 2 {
+		t.Errorf("check should run once per verifier, got %d calls", calls["schema"])
+	}
+}
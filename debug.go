@@ -0,0 +1,34 @@
+package verifier
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+var debugMode int32
+
+// SetDebug enables or disables debug mode for verifiers created by NewDebug afterwards (default: disabled).
+// Debug mode is also enabled by building with `debug` build tag.
+func SetDebug(enabled bool) {
+	value := int32(0)
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&debugMode, value)
+}
+
+// NewDebug creates verification instance, which behavior depends on debug mode, see SetDebug.
+// In debug mode it panics with PanicError on the first failed check
+// and reports unhandled verifications the same way as New.
+// Otherwise it tracks verification state silently, so aggressive invariant checks
+// can be kept in development and degrade gracefully in production without changing call sites.
+func NewDebug() *Verify {
+	v := &Verify{
+		creationStack: captureCreationStack(),
+	}
+	if atomic.LoadInt32(&debugMode) == 1 {
+		v.panicOnFailure = true
+		runtime.SetFinalizer(v, printWarningOnUncheckedVerification)
+	}
+	return v
+}
@@ -0,0 +1,7 @@
+//go:build debug
+
+package verifier
+
+func init() {
+	SetDebug(true)
+}
@@ -0,0 +1,48 @@
+package verifier_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+)
+
+func TestNewDebug_enabled(t *testing.T) {
+	verifier.SetDebug(true)
+	defer verifier.SetDebug(false)
+
+	verify := verifier.NewDebug()
+	verify.That(true, "balance should be positive")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	defer func() {
+		panicObj := recover()
+		panicErr, ok := panicObj.(verifier.PanicError)
+		if !ok {
+			t.Fatalf("unexpected panic: %v", panicObj)
+		}
+		if !errors.Is(panicErr, verifier.ErrVerificationFailed) {
+			t.Errorf("panic should wrap ErrVerificationFailed: %s", panicErr)
+		}
+		if panicErr.Error() != "verification failure: balance should be positive" {
+			t.Errorf("unexpected panic message: %s", panicErr)
+		}
+	}()
+	verify.That(false, "balance should be %s", "positive")
+	t.Fatal("failed check should panic")
+}
+
+func TestNewDebug_disabled(t *testing.T) {
+	verifier.SetDebug(false)
+
+	verify := verifier.NewDebug()
+	verify.That(false, "balance should be %s", "positive")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "balance should be positive" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
@@ -0,0 +1,122 @@
+package verifier
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+)
+
+// encodedVerify is a transferable state of verifier.
+type encodedVerify struct {
+	Error     string
+	Errors    []string
+	Failed    bool
+	Findings  map[Severity][]string
+	RequestID string
+	Records   []CheckResult
+	Fields    map[string]interface{}
+}
+
+// MarshalBinary encodes verification result: error message, messages of lower severity checks,
+// request ID, recorded checks and fields attached using WithContextFields,
+// so it can be transferred across process boundaries.
+// Field values are encoded using encoding/gob, so values of custom types must be registered using gob.Register.
+// Creation stack, error factory and other settings are not encoded.
+func (v *Verify) MarshalBinary() ([]byte, error) {
+	if v == nil {
+		return nil, errors.New("verifier instance is nil")
+	}
+	state := encodedVerify{
+		Findings:  v.findings,
+		RequestID: v.requestID,
+		Records:   v.records,
+		Fields:    v.fields,
+	}
+	if v.err != nil {
+		state.Error = v.err.Error()
+		state.Errors = errorMessages(v.err)
+		state.Failed = true
+	}
+	result := &bytes.Buffer{}
+	err := gob.NewEncoder(result).Encode(state)
+	if err != nil {
+		return nil, err
+	}
+	return result.Bytes(), nil
+}
+
+// UnmarshalBinary decodes verification result, encoded by MarshalBinary, into verifier.
+// Decoded error has the same message as original one and wraps ErrVerificationFailed,
+// but its type and other wrapped errors are lost.
+// Error combining several failures, e.g. produced by Aggregator, is decoded as combination of the same messages.
+// If decoded state has recorded checks, verifier keeps recording subsequent checks, same as one created by NewRecording.
+func (v *Verify) UnmarshalBinary(data []byte) error {
+	if v == nil {
+		return errors.New("verifier instance is nil")
+	}
+	var state encodedVerify
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&state)
+	if err != nil {
+		return err
+	}
+	v.err = nil
+	if state.Failed {
+		v.err = &verificationError{err: errors.New(state.Error)}
+	}
+	if state.Failed && len(state.Errors) > 1 {
+		combined := make(multiError, 0, len(state.Errors))
+		for _, message := range state.Errors {
+			combined = append(combined, errors.New(message))
+		}
+		v.err = &verificationError{err: combined}
+	}
+	v.findings = state.Findings
+	v.requestID = state.RequestID
+	v.records = state.Records
+	if len(state.Records) > 0 {
+		v.recording = true
+	}
+	v.fields = state.Fields
+	return nil
+}
+
+// errorMessages returns messages of all errors combined in err, or message of err itself.
+func errorMessages(err error) []string {
+	var combined multiError
+	if !errors.As(err, &combined) || combined.Error() != err.Error() {
+		return []string{err.Error()}
+	}
+	messages := make([]string, 0, len(combined))
+	for _, e := range combined {
+		messages = append(messages, e.Error())
+	}
+	return messages
+}
+
+// jsonVerify is a JSON representation of verification result.
+type jsonVerify struct {
+	Success  bool     `json:"success"`
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// MarshalJSON encodes verification result as `{"success": bool, "errors": [...], "warnings": [...]}`,
+// so clients get complete validation picture in one payload.
+// Error combining several failures, e.g. produced by Aggregator, is encoded as separate errors.
+// Warnings are messages of failed SeverityWarning checks and don't affect success,
+// they are omitted if there are none.
+func (v *Verify) MarshalJSON() ([]byte, error) {
+	if v == nil {
+		return nil, errors.New("verifier instance is nil")
+	}
+	result := jsonVerify{
+		Success:  v.err == nil,
+		Errors:   []string{},
+		Warnings: v.BySeverity(SeverityWarning),
+	}
+	if v.err != nil {
+		result.Errors = errorMessages(v.err)
+	}
+	return json.Marshal(result)
+}
@@ -0,0 +1,224 @@
+package verifier_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+)
+
+func TestVerifier_MarshalBinary_success(t *testing.T) {
+	verify := verifier.NewRecording()
+	verify.That(true, "transfer can't be nil")
+	data, err := verify.MarshalBinary()
+	if err != nil {
+		t.Fatalf("can't marshal verifier: %s", err)
+	}
+	if verify.GetError() != nil {
+		t.Fatal("verifier should be empty")
+	}
+
+	restored := &verifier.Verify{}
+	err = restored.UnmarshalBinary(data)
+	if err != nil {
+		t.Fatalf("can't unmarshal verifier: %s", err)
+	}
+	if restored.GetError() != nil {
+		t.Errorf("restored verifier should be empty: %s", restored.GetError())
+	}
+	if !reflect.DeepEqual(restored.Record(), verify.Record()) {
+		t.Errorf("unexpected restored record: %v", restored.Record())
+	}
+}
+
+func TestVerifier_MarshalBinary_recording_IsValid(t *testing.T) {
+	verify := verifier.NewRecording()
+	verify.That(true, "transfer can't be nil")
+	data, err := verify.MarshalBinary()
+	if err != nil {
+		t.Fatalf("can't marshal verifier: %s", err)
+	}
+	if verify.GetError() != nil {
+		t.Fatal("verifier should be empty")
+	}
+
+	restored := verifier.New()
+	err = restored.UnmarshalBinary(data)
+	if err != nil {
+		t.Fatalf("can't unmarshal verifier: %s", err)
+	}
+	if !restored.IsValid() {
+		t.Error("restored verifier should be valid")
+	}
+	restored.That(true, "person can't be nil")
+	expected := []verifier.CheckResult{
+		{Message: "transfer can't be nil", Passed: true},
+		{Message: "person can't be nil", Passed: true},
+	}
+	if !reflect.DeepEqual(restored.Record(), expected) {
+		t.Errorf("unexpected restored record: %v", restored.Record())
+	}
+	if restored.GetError() != nil {
+		t.Errorf("restored verifier should be empty: %s", restored.GetError())
+	}
+}
+
+func TestVerifier_MarshalBinary_failure(t *testing.T) {
+	verify := verifier.New().WithRequestID("req-42")
+	verify.ThatSeverity(verifier.SeverityWarning, false, "password is weak")
+	verify.ThatSeverity(verifier.SeverityInfo, false, "first login")
+	verify.That(false, "user is blocked")
+	data, err := verify.MarshalBinary()
+	if err != nil {
+		t.Fatalf("can't marshal verifier: %s", err)
+	}
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+
+	restored := &verifier.Verify{}
+	err = restored.UnmarshalBinary(data)
+	if err != nil {
+		t.Fatalf("can't unmarshal verifier: %s", err)
+	}
+	if restored.GetError() == nil || restored.GetError().Error() != "user is blocked" {
+		t.Errorf("unexpected restored error: %v", restored.GetError())
+	}
+	if !errors.Is(restored.GetError(), verifier.ErrVerificationFailed) {
+		t.Errorf("restored error should wrap ErrVerificationFailed")
+	}
+	for _, sev := range []verifier.Severity{verifier.SeverityError, verifier.SeverityWarning, verifier.SeverityInfo} {
+		if !reflect.DeepEqual(restored.BySeverity(sev), verify.BySeverity(sev)) {
+			t.Errorf("unexpected restored %s messages: %q", sev, restored.BySeverity(sev))
+		}
+	}
+}
+
+func TestVerifier_MarshalBinary_multiple_failures(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextKey("traceID"), "4bf92f35")
+	aggregator := &verifier.Aggregator{}
+	extract := verifier.New()
+	extract.That(false, "source is unavailable")
+	aggregator.Add("extract", extract)
+	load := verifier.New()
+	load.That(false, "table is locked")
+	aggregator.Add("load", load)
+	verify := aggregator.Result().WithContextFields(ctx, contextKey("traceID"))
+	data, err := verify.MarshalBinary()
+	if err != nil {
+		t.Fatalf("can't marshal verifier: %s", err)
+	}
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+
+	restored := &verifier.Verify{}
+	err = restored.UnmarshalBinary(data)
+	if err != nil {
+		t.Fatalf("can't unmarshal verifier: %s", err)
+	}
+	if restored.GetError() == nil || restored.GetError().Error() != verify.GetError().Error() {
+		t.Errorf("unexpected restored error: %v", restored.GetError())
+	}
+	if !errors.Is(restored.GetError(), verifier.ErrVerificationFailed) {
+		t.Errorf("restored error should wrap ErrVerificationFailed")
+	}
+	if !reflect.DeepEqual(restored.FieldKeys(), []string{"traceID"}) {
+		t.Errorf("unexpected restored fields: %v", restored.FieldKeys())
+	}
+}
+
+func TestVerifier_UnmarshalBinary_invalid(t *testing.T) {
+	verify := &verifier.Verify{}
+	if verify.UnmarshalBinary([]byte("garbage")) == nil {
+		t.Error("invalid data should not be decoded")
+	}
+}
+
+func TestVerifier_MarshalJSON(t *testing.T) {
+	testCases := map[string]struct {
+		verify   func(v *verifier.Verify)
+		expected string
+	}{
+		"success": {
+			verify: func(v *verifier.Verify) {
+				v.That(true, "amount should be positive")
+			},
+			expected: `{"success":true,"errors":[]}`,
+		},
+		"errors only": {
+			verify: func(v *verifier.Verify) {
+				v.That(false, "amount should be positive")
+			},
+			expected: `{"success":false,"errors":["amount should be positive"]}`,
+		},
+		"warnings only": {
+			verify: func(v *verifier.Verify) {
+				v.ThatSeverity(verifier.SeverityWarning, false, "amount is unusually large")
+				v.ThatSeverity(verifier.SeverityInfo, false, "amount is rounded")
+			},
+			expected: `{"success":true,"errors":[],"warnings":["amount is unusually large"]}`,
+		},
+		"mixed": {
+			verify: func(v *verifier.Verify) {
+				v.That(false, "currency should be set")
+				v.ThatSeverity(verifier.SeverityWarning, false, "amount is unusually large")
+			},
+			expected: `{"success":false,"errors":["currency should be set"],"warnings":["amount is unusually large"]}`,
+		},
+	}
+	for name, testCase := range testCases {
+		verify := verifier.New()
+		testCase.verify(verify)
+		data, err := json.Marshal(verify)
+		if err != nil {
+			t.Fatalf("can't marshal verifier for %s: %s", name, err)
+		}
+		if string(data) != testCase.expected {
+			t.Errorf("unexpected JSON for %s: %s", name, data)
+		}
+		verify.GetError()
+	}
+}
+
+func TestVerifier_MarshalJSON_multiple_failures(t *testing.T) {
+	aggregator := &verifier.Aggregator{}
+	extract := verifier.New()
+	extract.That(false, "source is unavailable")
+	aggregator.Add("extract", extract)
+	load := verifier.New()
+	load.That(false, "table is locked")
+	aggregator.Add("load", load)
+	verify := aggregator.Result()
+
+	expected := `{"success":false,"errors":["extract: source is unavailable","load: table is locked"]}`
+	data, err := json.Marshal(verify)
+	if err != nil {
+		t.Fatalf("can't marshal verifier: %s", err)
+	}
+	if string(data) != expected {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	encoded, err := verify.MarshalBinary()
+	if err != nil {
+		t.Fatalf("can't marshal verifier: %s", err)
+	}
+	restored := &verifier.Verify{}
+	err = restored.UnmarshalBinary(encoded)
+	if err != nil {
+		t.Fatalf("can't unmarshal verifier: %s", err)
+	}
+	data, err = json.Marshal(restored)
+	if err != nil {
+		t.Fatalf("can't marshal restored verifier: %s", err)
+	}
+	if string(data) != expected {
+		t.Errorf("unexpected JSON of restored verifier: %s", data)
+	}
+	verify.GetError()
+	restored.GetError()
+}
@@ -0,0 +1,156 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrVerificationFailed is wrapped by every error produced by default error factory,
+// so failures can be detected using `errors.Is(err, verifier.ErrVerificationFailed)`
+// regardless of the specific message.
+// Errors passed to Verify.WithError and errors built by custom factories are returned as is.
+var ErrVerificationFailed = errors.New("verification failed")
+
+// verificationError is produced by default error factory.
+// It keeps formatted message as is and wraps ErrVerificationFailed.
+type verificationError struct {
+	err error
+}
+
+func newVerificationError(message string, args ...interface{}) error {
+	return &verificationError{err: fmt.Errorf(message, args...)}
+}
+
+func (e *verificationError) Error() string {
+	return e.err.Error()
+}
+
+// Is reports that verification error is ErrVerificationFailed.
+func (e *verificationError) Is(target error) bool {
+	return target == ErrVerificationFailed
+}
+
+// Unwrap returns error built from message,
+// so errors wrapped using %w in verification message can be found as well.
+func (e *verificationError) Unwrap() error {
+	return e.err
+}
+
+// ContainsError reports whether verification failure matches target using `errors.Is`.
+// It's intended for control flow, so unlike Verify.GetError it doesn't mark verifier as checked.
+func (v *Verify) ContainsError(target error) bool {
+	if v == nil {
+		return false
+	}
+	return v.err != nil && errors.Is(v.err, target)
+}
+
+// ErrorMessageEquals verifies that err is not nil and its message is equal to expected.
+// On failure, message is followed by actual error message, or by notice that error is nil.
+func (v *Verify) ErrorMessageEquals(err error, expected string, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if err == nil {
+			return v.errorfWithDetail(message, args, "error is nil")
+		}
+		if err.Error() != expected {
+			return v.errorfWithDetail(message, args, "error message is %q", err.Error())
+		}
+		return nil
+	})
+}
+
+// ErrorChainLen verifies that err wraps exactly expected number of errors,
+// i.e. `errors.Unwrap` succeeds expected number of times in a row. Nil error has chain length 0.
+// On failure, message is followed by actual chain length.
+func ErrorChainLen(v *Verify, err error, expected int, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		length := 0
+		for unwrapped := errors.Unwrap(err); unwrapped != nil; unwrapped = errors.Unwrap(unwrapped) {
+			length++
+		}
+		if length != expected {
+			return v.errorfWithDetail(message, args, "chain length is %d", length)
+		}
+		return nil
+	})
+}
+
+// PanicError is a value, Verify.PanicOnError panics with.
+// Use type assertion on recovered value to distinguish verification failures
+// from other panics and to extract original verification error.
+type PanicError struct {
+	Err error
+}
+
+func (e PanicError) Error() string {
+	return "verification failure: " + e.Err.Error()
+}
+
+func (e PanicError) String() string {
+	return e.Error()
+}
+
+// Unwrap returns original verification error.
+func (e PanicError) Unwrap() error {
+	return e.Err
+}
+
+// prefixedError adds prefix to the message of wrapped error.
+type prefixedError struct {
+	prefix string
+	err    error
+}
+
+func (e *prefixedError) Error() string {
+	return e.prefix + ": " + e.err.Error()
+}
+
+func (e *prefixedError) Unwrap() error {
+	return e.err
+}
+
+// multiError combines several errors, its message is messages of all errors separated by "; ".
+type multiError []error
+
+func (e multiError) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e multiError) Unwrap() []error {
+	return e
+}
+
+// locatedError adds location of failed check to the message of wrapped error.
+type locatedError struct {
+	err      error
+	location string
+}
+
+func (e *locatedError) Error() string {
+	return e.err.Error() + " (" + e.location + ")"
+}
+
+func (e *locatedError) Unwrap() error {
+	return e.err
+}
+
+// DetailedError is a verification error with structured details,
+// attached to the failed check using Verify.WithDetail or to the whole verifier using Verify.WithContextFields.
+type DetailedError struct {
+	Err     error
+	Details map[string]interface{}
+}
+
+func (e *DetailedError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns original verification error.
+func (e *DetailedError) Unwrap() error {
+	return e.Err
+}
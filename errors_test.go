@@ -0,0 +1,105 @@
+package verifier_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/storozhukBM/verifier"
+)
+
+func TestVerifier_ErrorMessageEquals(t *testing.T) {
+	err := fmt.Errorf("can't save user: %w", errors.New("connection refused"))
+	verify := verifier.New()
+	verify.ErrorMessageEquals(err, "can't save user: connection refused", "unexpected error")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verify.ErrorMessageEquals(err, "can't save user", "unexpected %s", "error")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != `unexpected error: error message is "can't save user: connection refused"` {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verify.ErrorMessageEquals(nil, "", "unexpected error")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected error: error is nil" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+var errInsufficientFunds = errors.New("insufficient funds")
+
+func TestVerifier_ContainsError(t *testing.T) {
+	verify := verifier.New()
+	verify.That(true, "transfer can't be nil")
+	if verify.ContainsError(errInsufficientFunds) {
+		t.Error("successful verifier should not contain errors")
+	}
+
+	verify.That(false, "can't transfer: %w", errInsufficientFunds)
+	if !verify.ContainsError(errInsufficientFunds) {
+		t.Error("verifier should contain target error")
+	}
+	if !verify.ContainsError(verifier.ErrVerificationFailed) {
+		t.Error("verifier should contain ErrVerificationFailed")
+	}
+	if verify.ContainsError(errors.New("insufficient funds")) {
+		t.Error("verifier should not contain other error")
+	}
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+}
+
+func TestVerifier_ContainsError_does_not_mark_checked(t *testing.T) {
+	localBuffer := &safeBuffer{}
+	verifier.SetUnhandledVerificationsWriter(localBuffer)
+	defer verifier.SetUnhandledVerificationsWriter(os.Stdout)
+
+	verify := verifier.New()
+	verify.WithError(false, errInsufficientFunds)
+	if !verify.ContainsError(errInsufficientFunds) {
+		t.Error("verifier should contain target error")
+	}
+	verify = nil
+	runtime.GC()
+	time.Sleep(10 * time.Millisecond)
+
+	if !strings.HasPrefix(localBuffer.String(), "[ERROR] found unhandled verification: verification failure: insufficient funds") {
+		t.Fatalf("unexpected verifier buffer: %s", localBuffer)
+	}
+}
+
+func TestErrorChainLen(t *testing.T) {
+	root := errors.New("connection refused")
+	wrapped := fmt.Errorf("query users: %w", root)
+	doubleWrapped := fmt.Errorf("load profile: %w", wrapped)
+
+	verify := verifier.New()
+	verifier.ErrorChainLen(verify, nil, 0, "unexpected wrapping")
+	verifier.ErrorChainLen(verify, root, 0, "unexpected wrapping")
+	verifier.ErrorChainLen(verify, wrapped, 1, "unexpected wrapping")
+	verifier.ErrorChainLen(verify, doubleWrapped, 2, "unexpected wrapping")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.ErrorChainLen(verify, doubleWrapped, 1, "unexpected %s", "wrapping")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected wrapping: chain length is 2" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/storozhukBM/verifier
 
-go 1.12
+go 1.18
@@ -0,0 +1,28 @@
+// Package explain provides checks, that know the reason of their failure, for extension packages of verifier.
+package explain
+
+import "github.com/storozhukBM/verifier"
+
+// Check evaluates explanation, that returns empty string if verification passed,
+// or description of the failure reason otherwise.
+// On failure, message is followed by the reason, separated by ": ".
+// Passed check is recorded with message as is, without separator.
+// After the first failed verification explanation won't be evaluated.
+func Check(v *verifier.Verify, explanation func() string, message string, args ...interface{}) *verifier.Verify {
+	var reason string
+	return v.Predicate(func() bool {
+		reason = explanation()
+		return reason == ""
+	}, message+"%s", append(args[:len(args):len(args)], lazyReason(func() string { return reason }))...)
+}
+
+// lazyReason defers formatting of failure reason until message is actually formatted.
+type lazyReason func() string
+
+func (r lazyReason) String() string {
+	reason := r()
+	if reason == "" {
+		return ""
+	}
+	return ": " + reason
+}
@@ -0,0 +1,30 @@
+package explain_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+	"github.com/storozhukBM/verifier/internal/explain"
+)
+
+func TestCheck(t *testing.T) {
+	verify := verifier.NewRecording()
+	explain.Check(verify, func() string { return "" }, "transfer can't be nil")
+	explain.Check(verify, func() string { return "limit is 100" }, "amount of transfer %d is too large", 150)
+	explain.Check(verify, func() string {
+		t.Error("explanation should not be evaluated after failure")
+		return ""
+	}, "won't evaluate")
+	err := verify.GetError()
+	if err == nil || err.Error() != "amount of transfer 150 is too large: limit is 100" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []verifier.CheckResult{
+		{Message: "transfer can't be nil", Passed: true},
+		{Message: "amount of transfer 150 is too large: limit is 100", Passed: false},
+	}
+	if !reflect.DeepEqual(verify.Record(), expected) {
+		t.Errorf("unexpected record: %v", verify.Record())
+	}
+}
@@ -0,0 +1,51 @@
+package verifier
+
+import (
+	"bytes"
+	"io"
+)
+
+// ReaderLen verifies that reader yields exactly expected number of bytes.
+// Reader is consumed completely, so it can't be read again after verification.
+// On failure, message is followed by actual number of bytes or by read error.
+// After the first failed verification reader won't be read.
+func (v *Verify) ReaderLen(r io.Reader, expected int, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		n, err := io.Copy(io.Discard, r)
+		if err != nil {
+			return v.errorfWithDetail(message, args, "read failed after %d bytes: %v", n, err)
+		}
+		if n != int64(expected) {
+			return v.errorfWithDetail(message, args, "read %d bytes", n)
+		}
+		return nil
+	})
+}
+
+// HasBytePrefix verifies that data starts with prefix, e.g. with magic number of expected file format.
+// Empty prefix always passes.
+// On failure, message is followed by expected prefix and actual leading bytes of data in hex.
+func HasBytePrefix(v *Verify, data, prefix []byte, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if bytes.HasPrefix(data, prefix) {
+			return nil
+		}
+		leading := data
+		if len(leading) > len(prefix) {
+			leading = leading[:len(prefix)]
+		}
+		return v.errorfWithDetail(message, args, "expected prefix %x, got %x", prefix, leading)
+	})
+}
+
+// BytesNotEqual verifies that byte slices a and b differ, e.g. that re-encryption produced different ciphertext.
+// Slices are compared using bytes.Equal, so nil and empty slices are equal.
+// On failure, message is followed by the length of equal slices.
+func (v *Verify) BytesNotEqual(a, b []byte, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if !bytes.Equal(a, b) {
+			return nil
+		}
+		return v.errorfWithDetail(message, args, "both are equal %d bytes", len(a))
+	})
+}
@@ -0,0 +1,89 @@
+package verifier_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/storozhukBM/verifier"
+)
+
+func TestVerifier_ReaderLen(t *testing.T) {
+	verify := verifier.New()
+	verify.ReaderLen(strings.NewReader("0123456789"), 10, "unexpected payload size")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verify.ReaderLen(strings.NewReader("012345"), 10, "unexpected %s size", "payload")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected payload size: read 6 bytes" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestVerifier_ReaderLen_read_error(t *testing.T) {
+	failing := io.MultiReader(strings.NewReader("0123"), iotest.ErrReader(errors.New("connection reset")))
+	verify := verifier.New()
+	verify.ReaderLen(failing, 10, "unexpected payload size")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected payload size: read failed after 4 bytes: connection reset" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestHasBytePrefix(t *testing.T) {
+	pngMagic := []byte{0x89, 'P', 'N', 'G'}
+	verify := verifier.New()
+	verifier.HasBytePrefix(verify, []byte{0x89, 'P', 'N', 'G', '\r', '\n'}, pngMagic, "unsupported image format")
+	verifier.HasBytePrefix(verify, []byte("GIF89a"), nil, "unsupported image format")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := map[string][]byte{
+		"unsupported image format: expected prefix 89504e47, got 47494638": []byte("GIF89a"),
+		"unsupported image format: expected prefix 89504e47, got 8950":     {0x89, 'P'},
+	}
+	for message, data := range testCases {
+		verify := verifier.New()
+		verifier.HasBytePrefix(verify, data, pngMagic, "unsupported %s format", "image")
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %x", data)
+		}
+		if verify.GetError().Error() != message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
+
+func TestVerifier_BytesNotEqual(t *testing.T) {
+	verify := verifier.New()
+	verify.BytesNotEqual([]byte{0x1f, 0x8b, 0x08}, []byte{0x1f, 0x8b, 0x09}, "ciphertext should change")
+	verify.BytesNotEqual([]byte{0x1f, 0x8b}, []byte{0x1f, 0x8b, 0x08}, "ciphertext should change")
+	verify.BytesNotEqual(nil, []byte{0x00}, "ciphertext should change")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := map[string][2][]byte{
+		"ciphertext should change: both are equal 3 bytes": {{0x1f, 0x8b, 0x08}, {0x1f, 0x8b, 0x08}},
+		"ciphertext should change: both are equal 0 bytes": {nil, {}},
+	}
+	for message, pair := range testCases {
+		verify := verifier.New()
+		verify.BytesNotEqual(pair[0], pair[1], "%s should change", "ciphertext")
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %x", pair)
+		}
+		if verify.GetError().Error() != message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
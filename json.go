@@ -0,0 +1,150 @@
+package verifier
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// JSONRoundTrips verifies that value is not changed after it's marshaled to JSON
+// and unmarshaled back to the fresh instance of the same type.
+// Values are compared using `reflect.DeepEqual`, so round trip is lossless only if:
+//   - there are no unexported fields with non-zero values, because they are not serialized;
+//   - there are no interface fields, because they are decoded as generic JSON values (e.g. numbers as float64);
+//   - all maps have string, integer or encoding.TextMarshaler keys.
+//
+// On failure, message is followed by description of the problem.
+func JSONRoundTrips(v *Verify, value interface{}, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if value == nil {
+			return v.errorfWithDetail(message, args, "can't round trip untyped nil")
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return v.errorfWithDetail(message, args, "%v", err)
+		}
+		decoded := reflect.New(reflect.TypeOf(value))
+		err = json.Unmarshal(data, decoded.Interface())
+		if err != nil {
+			return v.errorfWithDetail(message, args, "%v", err)
+		}
+		if !reflect.DeepEqual(decoded.Elem().Interface(), value) {
+			return v.errorfWithDetail(message, args, "%+v changed to %+v", value, decoded.Elem().Interface())
+		}
+		return nil
+	})
+}
+
+// JSONEqual verifies that JSON documents a and b are structurally equal,
+// so differences in key order and whitespace don't matter.
+// On failure, message is followed by description of the problem.
+// Invalid JSON on either side is reported as failure.
+func JSONEqual(v *Verify, a, b []byte, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		var aValue, bValue interface{}
+		err := json.Unmarshal(a, &aValue)
+		if err != nil {
+			return v.errorfWithDetail(message, args, "invalid first JSON: %v", err)
+		}
+		err = json.Unmarshal(b, &bValue)
+		if err != nil {
+			return v.errorfWithDetail(message, args, "invalid second JSON: %v", err)
+		}
+		if !reflect.DeepEqual(aValue, bValue) {
+			return v.errorfWithDetail(message, args, "%s is not equal to %s", a, b)
+		}
+		return nil
+	})
+}
+
+// JSONHasPath verifies that JSON document contains value at path,
+// specified using dots for object keys and brackets for array indexes, like `user.addresses[0].city`.
+// Present null value is considered present.
+// On failure, message is followed by the first absent part of the path.
+// Invalid JSON document or path are reported as failures as well.
+func JSONHasPath(v *Verify, document []byte, path string, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		steps, ok := parseJSONPath(path)
+		if !ok {
+			return v.errorfWithDetail(message, args, "invalid path %q", path)
+		}
+		var current interface{}
+		err := json.Unmarshal(document, &current)
+		if err != nil {
+			return v.errorfWithDetail(message, args, "invalid JSON: %v", err)
+		}
+		traversed := ""
+		for _, step := range steps {
+			traversed += step.String(traversed == "")
+			var found bool
+			current, found = step.apply(current)
+			if !found {
+				return v.errorfWithDetail(message, args, "%s is absent", traversed)
+			}
+		}
+		return nil
+	})
+}
+
+// jsonPathStep is either object key or array index.
+type jsonPathStep struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+func (s jsonPathStep) String(first bool) string {
+	if s.isIndex {
+		return "[" + strconv.Itoa(s.index) + "]"
+	}
+	if first {
+		return s.key
+	}
+	return "." + s.key
+}
+
+func (s jsonPathStep) apply(value interface{}) (interface{}, bool) {
+	if s.isIndex {
+		array, ok := value.([]interface{})
+		if !ok || s.index >= len(array) {
+			return nil, false
+		}
+		return array[s.index], true
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	result, ok := object[s.key]
+	return result, ok
+}
+
+func parseJSONPath(path string) ([]jsonPathStep, bool) {
+	var steps []jsonPathStep
+	for i, part := range strings.Split(path, ".") {
+		bracket := strings.IndexByte(part, '[')
+		if bracket < 0 {
+			bracket = len(part)
+		}
+		key, indexes := part[:bracket], part[bracket:]
+		if key != "" {
+			steps = append(steps, jsonPathStep{key: key})
+		} else if i > 0 || indexes == "" {
+			return nil, false
+		}
+		for indexes != "" {
+			end := strings.IndexByte(indexes, ']')
+			if indexes[0] != '[' || end < 0 {
+				return nil, false
+			}
+			index, err := strconv.Atoi(indexes[1:end])
+			if err != nil || index < 0 {
+				return nil, false
+			}
+			steps = append(steps, jsonPathStep{index: index, isIndex: true})
+			indexes = indexes[end+1:]
+		}
+	}
+	return steps, true
+}
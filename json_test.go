@@ -0,0 +1,127 @@
+package verifier_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+)
+
+type transferDTO struct {
+	Destination string            `json:"destination"`
+	Amount      int64             `json:"amount"`
+	Tags        []string          `json:"tags"`
+	Meta        map[string]string `json:"meta"`
+}
+
+func TestJSONRoundTrips(t *testing.T) {
+	transfer := transferDTO{
+		Destination: "UA213223130000026007233566001",
+		Amount:      42,
+		Tags:        []string{"urgent"},
+		Meta:        map[string]string{"source": "mobile"},
+	}
+	verify := verifier.New()
+	verifier.JSONRoundTrips(verify, transfer, "transfer should be serializable")
+	verifier.JSONRoundTrips(verify, &transfer, "transfer should be serializable")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	type withCallback struct {
+		Name     string
+		Callback func()
+	}
+	verifier.JSONRoundTrips(verify, withCallback{Name: "job"}, "job should be serializable")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if !strings.HasPrefix(verify.GetError().Error(), "job should be serializable: json: unsupported type: func()") {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestJSONRoundTrips_lossy(t *testing.T) {
+	type withUnexported struct {
+		Name   string
+		secret string
+	}
+	verify := verifier.New()
+	verifier.JSONRoundTrips(verify, withUnexported{Name: "user", secret: "password"}, "user should be serializable")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "user should be serializable: {Name:user secret:password} changed to {Name:user secret:}" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+const webhookPayload = `{
+	"event": "user.updated",
+	"user": {
+		"name": "John",
+		"nickname": null,
+		"addresses": [{"city": "Kyiv"}, {"city": "Lviv", "zip": "79000"}]
+	}
+}`
+
+func TestJSONHasPath(t *testing.T) {
+	verify := verifier.New()
+	verifier.JSONHasPath(verify, []byte(webhookPayload), "event", "invalid payload")
+	verifier.JSONHasPath(verify, []byte(webhookPayload), "user.nickname", "invalid payload")
+	verifier.JSONHasPath(verify, []byte(webhookPayload), "user.addresses[1].zip", "invalid payload")
+	verifier.JSONHasPath(verify, []byte(`[[1, 2], [3]]`), "[1][0]", "invalid payload")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := []struct {
+		name     string
+		document string
+		path     string
+		message  string
+	}{
+		{"absent key", webhookPayload, "user.addresses[0].zip", "invalid payload: user.addresses[0].zip is absent"},
+		{"index out of range", webhookPayload, "user.addresses[2].city", "invalid payload: user.addresses[2] is absent"},
+		{"not an array", webhookPayload, "user.name[0]", "invalid payload: user.name[0] is absent"},
+		{"invalid path", webhookPayload, "user..name", `invalid payload: invalid path "user..name"`},
+		{"invalid JSON", `{"user": `, "user", "invalid payload: invalid JSON: unexpected end of JSON input"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			verify := verifier.New()
+			verifier.JSONHasPath(verify, []byte(testCase.document), testCase.path, "invalid %s", "payload")
+			if verify.GetError() == nil {
+				t.Fatal("verifier should be filled")
+			}
+			if verify.GetError().Error() != testCase.message {
+				t.Errorf("unexpected error message: %s", verify.GetError())
+			}
+		})
+	}
+}
+
+func TestJSONEqual(t *testing.T) {
+	verify := verifier.New()
+	verifier.JSONEqual(verify, []byte(`{"id": 1, "tags": ["a", "b"]}`), []byte(`{"tags":["a","b"],"id":1.0}`), "unexpected response")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.JSONEqual(verify, []byte(`{"id": 1}`), []byte(`{"id": 2}`), "unexpected %s", "response")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != `unexpected response: {"id": 1} is not equal to {"id": 2}` {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.JSONEqual(verify, []byte(`{"id": 1}`), []byte(`{"id": `), "unexpected response")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected response: invalid second JSON: unexpected end of JSON input" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
@@ -0,0 +1,159 @@
+package verifier
+
+import (
+	"math"
+	"math/bits"
+	"reflect"
+)
+
+// Integer is a constraint for all integer types.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is a constraint for all floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is a constraint for all integer and floating-point types.
+type Number interface {
+	Integer | Float
+}
+
+// Ordered is a constraint for all types supporting ordering operators.
+type Ordered interface {
+	Integer | Float | ~string
+}
+
+// WithinPercent verifies that actual differs from expected by no more than percent% of expected.
+// If expected is zero, actual should be exactly zero.
+// On failure, message is followed by both values.
+func WithinPercent(v *Verify, actual, expected, percent float64, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if math.Abs(actual-expected) <= math.Abs(expected)*percent/100 {
+			return nil
+		}
+		return v.errorfWithDetail(message, args, "%v differs from %v by more than %v%%", actual, expected, percent)
+	})
+}
+
+// ValidEnum verifies that integer-backed enum value is within [min, max] range,
+// intended for enums declared using iota.
+// On failure, message is followed by out of range value.
+func ValidEnum[T ~int](v *Verify, value T, min, max T, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if min <= value && value <= max {
+			return nil
+		}
+		return v.errorfWithDetail(message, args, "%d is out of range [%d, %d]", value, min, max)
+	})
+}
+
+// OnStep verifies that value lies on the grid starting at origin with step,
+// meaning that (value - origin) is an integer multiple of step.
+// For floating-point values comparison is exact, use OnStepEpsilon to tolerate rounding errors.
+// On failure, message is followed by the grid description. Zero step is reported as failure.
+func OnStep[T Number](v *Verify, value, origin, step T, message string, args ...interface{}) *Verify {
+	return onStep(v, value, origin, step, 0, message, args)
+}
+
+// OnStepEpsilon verifies that floating-point value lies on the grid starting at origin with step,
+// tolerating distance to the nearest grid point up to epsilon.
+// On failure, message is followed by the grid description. Zero step is reported as failure.
+func OnStepEpsilon[T Float](v *Verify, value, origin, step, epsilon T, message string, args ...interface{}) *Verify {
+	return onStep(v, value, origin, step, epsilon, message, args)
+}
+
+func onStep[T Number](v *Verify, value, origin, step, epsilon T, message string, args []interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if step == 0 {
+			return v.errorfWithDetail(message, args, "step is zero")
+		}
+		var half T = 1
+		half /= 2
+		onGrid := false
+		if half == 0 {
+			// distance and step are computed in uint64, so they don't overflow T, e.g. for int8 values -100 and 100
+			diff := uint64(value) - uint64(origin)
+			if value < origin {
+				diff = uint64(origin) - uint64(value)
+			}
+			stepSize := uint64(step)
+			if step < 0 {
+				stepSize = -stepSize
+			}
+			onGrid = diff%stepSize == 0
+		} else {
+			diff := float64(value) - float64(origin)
+			onGrid = math.Abs(math.Remainder(diff, float64(step))) <= float64(epsilon)
+		}
+		if onGrid {
+			return nil
+		}
+		return v.errorfWithDetail(message, args, "%v is not on the grid %v + n*%v", value, origin, step)
+	})
+}
+
+// FitsIn verifies that value can be converted to integer type T without overflow,
+// e.g. before down-casting it for binary protocol.
+// On failure, message is followed by the value and the name of type T.
+func FitsIn[T Integer](v *Verify, value int64, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		converted := T(value)
+		if int64(converted) == value && (converted < 0) == (value < 0) {
+			return nil
+		}
+		return v.errorfWithDetail(message, args, "%d overflows %T", value, converted)
+	})
+}
+
+// SlicesApproxEqual verifies that slices actual and expected have the same length
+// and their elements at each index differ by no more than epsilon. NaN elements are never equal.
+// On failure, message is followed by both lengths or by the first offending index and both elements.
+func SlicesApproxEqual(v *Verify, actual, expected []float64, epsilon float64, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if len(actual) != len(expected) {
+			return v.errorfWithDetail(message, args, "lengths are %d and %d", len(actual), len(expected))
+		}
+		for i := range actual {
+			if !(math.Abs(actual[i]-expected[i]) <= epsilon) {
+				return v.errorfWithDetail(message, args, "element %d differs: %v != %v", i, actual[i], expected[i])
+			}
+		}
+		return nil
+	})
+}
+
+// PopcountEquals verifies that number of set bits in value is equal to expected,
+// e.g. to check that exactly one flag of bitmask is set.
+// Bits of negative values are counted in two's complement representation of their own width,
+// so int8(-1) has 8 set bits.
+// On failure, message is followed by actual number of set bits.
+func PopcountEquals[T Integer](v *Verify, value T, expected int, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		raw := uint64(value)
+		if width := reflect.TypeOf(value).Bits(); width < 64 {
+			raw &= 1<<width - 1
+		}
+		if count := bits.OnesCount64(raw); count != expected {
+			return v.errorfWithDetail(message, args, "%d bits are set in %#b", count, raw)
+		}
+		return nil
+	})
+}
+
+// WithinStdDev verifies that value is within n standard deviations from mean, i.e. `|value - mean| <= n*stdDev`,
+// e.g. that measured metric is statistically normal. If stdDev is zero, value should be exactly equal to mean.
+// On failure, message is followed by actual deviation in standard deviations.
+func WithinStdDev(v *Verify, value, mean, stdDev float64, n float64, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if math.Abs(value-mean) <= n*stdDev {
+			return nil
+		}
+		return v.errorfWithDetail(
+			message, args, "%v deviates from %v by %v, more than %v standard deviations of %v",
+			value, mean, math.Abs(value-mean), n, stdDev,
+		)
+	})
+}
@@ -0,0 +1,272 @@
+package verifier_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+)
+
+func TestWithinPercent(t *testing.T) {
+	verify := verifier.New()
+	verifier.WithinPercent(verify, 104, 100, 5, "unexpected rate")
+	verifier.WithinPercent(verify, -0.96, -1, 5, "unexpected rate")
+	verifier.WithinPercent(verify, 0, 0, 5, "unexpected rate")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.WithinPercent(verify, 106, 100, 5, "unexpected %s", "rate")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected rate: 106 differs from 100 by more than 5%" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.WithinPercent(verify, 0.001, 0, 50, "unexpected rate")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+}
+
+type transferStatus int
+
+const (
+	transferPending transferStatus = iota
+	transferCompleted
+	transferFailed
+)
+
+func TestValidEnum(t *testing.T) {
+	verify := verifier.New()
+	verifier.ValidEnum(verify, transferPending, transferPending, transferFailed, "unknown status")
+	verifier.ValidEnum(verify, transferCompleted, transferPending, transferFailed, "unknown status")
+	verifier.ValidEnum(verify, transferFailed, transferPending, transferFailed, "unknown status")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.ValidEnum(verify, transferStatus(3), transferPending, transferFailed, "unknown %s", "status")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unknown status: 3 is out of range [0, 2]" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.ValidEnum(verify, transferStatus(-1), transferPending, transferFailed, "unknown status")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+}
+
+func TestOnStep(t *testing.T) {
+	verify := verifier.New()
+	verifier.OnStep(verify, 25, 0, 5, "price should snap to 5")
+	verifier.OnStep(verify, -7, 3, 5, "price should snap to 5")
+	verifier.OnStep(verify, uint(2), 5, 3, "price should snap to 3")
+	verifier.OnStep(verify, 1.75, 0.5, 0.25, "slider should snap to 0.25")
+	verifier.OnStep[int8](verify, 100, -100, 50, "offset should snap to 50")
+	verifier.OnStep[int8](verify, -128, 127, -5, "offset should snap to 5")
+	verifier.OnStep[int64](verify, math.MaxInt64, math.MinInt64+1, 2, "offset should snap to 2")
+	verifier.OnStep[uint8](verify, 5, 255, 25, "offset should snap to 25")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.OnStep(verify, 27, 0, 5, "%s should snap to 5", "price")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "price should snap to 5: 27 is not on the grid 0 + n*5" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.OnStep(verify, 1.8, 0.5, 0.25, "slider should snap to 0.25")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+
+	verify = verifier.New()
+	verifier.OnStep[int8](verify, 100, -100, 30, "offset should snap to 30")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "offset should snap to 30: 100 is not on the grid -100 + n*30" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.OnStep(verify, 25, 0, 0, "price should snap to step")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "price should snap to step: step is zero" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestOnStepEpsilon(t *testing.T) {
+	verify := verifier.New()
+	verifier.OnStepEpsilon(verify, 0.1+0.2, 0, 0.1, 1e-9, "should snap to 0.1")
+	verifier.OnStepEpsilon(verify, 0.2999999, 0, 0.1, 1e-6, "should snap to 0.1")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.OnStepEpsilon(verify, 0.35, 0, 0.1, 1e-9, "should snap to 0.1")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "should snap to 0.1: 0.35 is not on the grid 0 + n*0.1" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestFitsIn(t *testing.T) {
+	verify := verifier.New()
+	verifier.FitsIn[int8](verify, math.MaxInt8, "field should fit")
+	verifier.FitsIn[int8](verify, math.MinInt8, "field should fit")
+	verifier.FitsIn[int16](verify, math.MinInt16, "field should fit")
+	verifier.FitsIn[int32](verify, math.MaxInt32, "field should fit")
+	verifier.FitsIn[uint8](verify, math.MaxUint8, "field should fit")
+	verifier.FitsIn[uint16](verify, 0, "field should fit")
+	verifier.FitsIn[uint64](verify, math.MaxInt64, "field should fit")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := []struct {
+		fits    func(v *verifier.Verify) *verifier.Verify
+		message string
+	}{
+		{
+			fits: func(v *verifier.Verify) *verifier.Verify {
+				return verifier.FitsIn[int8](v, math.MaxInt8+1, "field %s should fit", "len")
+			},
+			message: "field len should fit: 128 overflows int8",
+		},
+		{
+			fits: func(v *verifier.Verify) *verifier.Verify {
+				return verifier.FitsIn[int16](v, math.MinInt16-1, "field %s should fit", "len")
+			},
+			message: "field len should fit: -32769 overflows int16",
+		},
+		{
+			fits: func(v *verifier.Verify) *verifier.Verify {
+				return verifier.FitsIn[int32](v, math.MaxInt32+1, "field %s should fit", "len")
+			},
+			message: "field len should fit: 2147483648 overflows int32",
+		},
+		{
+			fits: func(v *verifier.Verify) *verifier.Verify {
+				return verifier.FitsIn[uint8](v, math.MaxUint8+1, "field %s should fit", "len")
+			},
+			message: "field len should fit: 256 overflows uint8",
+		},
+		{
+			fits: func(v *verifier.Verify) *verifier.Verify {
+				return verifier.FitsIn[uint64](v, -1, "field %s should fit", "len")
+			},
+			message: "field len should fit: -1 overflows uint64",
+		},
+	}
+	for _, testCase := range testCases {
+		verify := testCase.fits(verifier.New())
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %s", testCase.message)
+		}
+		if verify.GetError().Error() != testCase.message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
+
+func TestSlicesApproxEqual(t *testing.T) {
+	expected := []float64{0.1, 0.2, 0.3}
+	verify := verifier.New()
+	verifier.SlicesApproxEqual(verify, []float64{0.1, 0.2, 0.1 + 0.2}, expected, 1e-9, "unexpected weights")
+	verifier.SlicesApproxEqual(verify, nil, []float64{}, 1e-9, "unexpected weights")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := []struct {
+		actual  []float64
+		message string
+	}{
+		{[]float64{0.1, 0.2}, "unexpected weights: lengths are 2 and 3"},
+		{[]float64{0.1, 0.25, 0.3}, "unexpected weights: element 1 differs: 0.25 != 0.2"},
+		{[]float64{0.1, 0.2, math.NaN()}, "unexpected weights: element 2 differs: NaN != 0.3"},
+	}
+	for _, testCase := range testCases {
+		verify := verifier.New()
+		verifier.SlicesApproxEqual(verify, testCase.actual, expected, 1e-9, "unexpected %s", "weights")
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %v", testCase.actual)
+		}
+		if verify.GetError().Error() != testCase.message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
+
+func TestPopcountEquals(t *testing.T) {
+	type permission uint8
+	const (
+		read permission = 1 << iota
+		write
+		execute
+	)
+
+	verify := verifier.New()
+	verifier.PopcountEquals(verify, permission(0), 0, "unexpected permissions")
+	verifier.PopcountEquals(verify, write, 1, "unexpected permissions")
+	verifier.PopcountEquals(verify, read|write|execute, 3, "unexpected permissions")
+	verifier.PopcountEquals(verify, int8(-1), 8, "unexpected permissions")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.PopcountEquals(verify, read|execute, 1, "exactly one %s expected", "permission")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "exactly one permission expected: 2 bits are set in 0b101" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestWithinStdDev(t *testing.T) {
+	verify := verifier.New()
+	verifier.WithinStdDev(verify, 130, 100, 10, 3, "latency should be normal")
+	verifier.WithinStdDev(verify, 75, 100, 10, 3, "latency should be normal")
+	verifier.WithinStdDev(verify, 100, 100, 0, 3, "latency should be normal")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := []struct {
+		value   float64
+		stdDev  float64
+		message string
+	}{
+		{131, 10, "latency should be normal: 131 deviates from 100 by 31, more than 3 standard deviations of 10"},
+		{60, 10, "latency should be normal: 60 deviates from 100 by 40, more than 3 standard deviations of 10"},
+		{100.5, 0, "latency should be normal: 100.5 deviates from 100 by 0.5, more than 3 standard deviations of 0"},
+	}
+	for _, testCase := range testCases {
+		verify := verifier.New()
+		verifier.WithinStdDev(verify, testCase.value, 100, testCase.stdDev, 3, "%s should be normal", "latency")
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %v", testCase.value)
+		}
+		if verify.GetError().Error() != testCase.message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
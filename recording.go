@@ -0,0 +1,67 @@
+package verifier
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+)
+
+// NewRecording creates verification instance that tracks verification state, same as New,
+// and also records every evaluated check, both passed and failed.
+// Checks after the first failure are still not evaluated, so they are not recorded.
+// Use Verify.Record or Verify.ResultsTable to see recorded checks.
+func NewRecording() *Verify {
+	v := &Verify{
+		creationStack: captureCreationStack(),
+		recording:     true,
+	}
+	runtime.SetFinalizer(v, printWarningOnUncheckedVerification)
+	return v
+}
+
+// CheckResult represents outcome of single evaluated check.
+// Message is formatted check message for passed checks or error message for failed ones.
+type CheckResult struct {
+	Message string
+	Passed  bool
+}
+
+// Record returns outcomes of all evaluated checks in order of evaluation.
+// Only verifiers created by NewRecording record checks, for others it's always empty.
+func (v *Verify) Record() []CheckResult {
+	if v == nil {
+		return nil
+	}
+	result := make([]CheckResult, len(v.records))
+	copy(result, v.records)
+	return result
+}
+
+// ResultsTable represents all recorded checks as aligned text table with their messages and PASS/FAIL statuses.
+// Only verifiers created by NewRecording record checks, for others table has no rows.
+func (v *Verify) ResultsTable() string {
+	if v == nil {
+		return "nil"
+	}
+	var result strings.Builder
+	writer := tabwriter.NewWriter(&result, 0, 4, 2, ' ', 0)
+	fmt.Fprint(writer, "CHECK\tSTATUS\n")
+	for _, r := range v.records {
+		status := "PASS"
+		if !r.Passed {
+			status = "FAIL"
+		}
+		fmt.Fprintf(writer, "%s\t%s\n", r.Message, status)
+	}
+	writer.Flush()
+	return result.String()
+}
+
+func (v *Verify) record(message string, args []interface{}) {
+	if v.err != nil {
+		v.records = append(v.records, CheckResult{Message: v.err.Error(), Passed: false})
+		return
+	}
+	v.records = append(v.records, CheckResult{Message: fmt.Sprintf(message, args...), Passed: true})
+}
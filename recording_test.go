@@ -0,0 +1,88 @@
+package verifier_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+)
+
+func TestVerifier_ResultsTable(t *testing.T) {
+	verify := verifier.NewRecording()
+	verify.That(true, "transfer can't be nil")
+	verify.That(false, "age should be 21 or higher, but yours: %d", 18)
+	verify.That(true, "won't evaluate")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+
+	table := verify.ResultsTable()
+	expected := "" +
+		"CHECK                                      STATUS\n" +
+		"transfer can't be nil                      PASS\n" +
+		"age should be 21 or higher, but yours: 18  FAIL\n"
+	if table != expected {
+		t.Errorf("unexpected results table:\n%s", table)
+	}
+	if strings.Contains(table, "won't evaluate") {
+		t.Errorf("checks after failure should not be recorded:\n%s", table)
+	}
+}
+
+func TestVerifier_ResultsTable_not_recording(t *testing.T) {
+	verify := verifier.New()
+	verify.That(true, "transfer can't be nil")
+	if verify.GetError() != nil {
+		t.Fatal("verifier should be empty")
+	}
+	if verify.ResultsTable() != "CHECK  STATUS\n" {
+		t.Errorf("unexpected results table:\n%s", verify.ResultsTable())
+	}
+}
+
+func TestVerifier_Record(t *testing.T) {
+	verify := verifier.NewRecording()
+	verify.That(true, "transfer can't be nil")
+	verify.Predicate(func() bool { return true }, "person can't be nil")
+	verify.That(false, "transfer amount should be greater than zero")
+	verify.That(true, "won't evaluate")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+
+	expected := []verifier.CheckResult{
+		{Message: "transfer can't be nil", Passed: true},
+		{Message: "person can't be nil", Passed: true},
+		{Message: "transfer amount should be greater than zero", Passed: false},
+	}
+	record := verify.Record()
+	if len(record) != len(expected) {
+		t.Fatalf("unexpected record length: %d", len(record))
+	}
+	for i := range expected {
+		if record[i] != expected[i] {
+			t.Errorf("unexpected check result #%d: %+v", i, record[i])
+		}
+	}
+}
+
+func TestVerifier_Record_WithError(t *testing.T) {
+	verify := verifier.NewRecording()
+	verify.WithError(true, nil)
+	verify.WithError(true, errors.New("transfer can't be nil"))
+	verify.WithError(false, errors.New("transfer amount should be greater than zero"))
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+
+	expected := []verifier.CheckResult{
+		{Message: "check without error", Passed: true},
+		{Message: "transfer can't be nil", Passed: true},
+		{Message: "transfer amount should be greater than zero", Passed: false},
+	}
+	if !reflect.DeepEqual(verify.Record(), expected) {
+		t.Errorf("unexpected record: %v", verify.Record())
+	}
+}
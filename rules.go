@@ -0,0 +1,65 @@
+package verifier
+
+import (
+	"fmt"
+	"reflect"
+	"unicode/utf8"
+)
+
+// FieldRule is a reusable verification of struct field, bound to specific struct obj as NamedCheck named after the field.
+// Field value can't be captured by name without the struct, so instead of plain func(*Verify)
+// rule takes obj explicitly and returns check that can be run using Verify.RunChecks.
+// Use Verify.ApplyRules to bind and run several rules at once, failure message is prefixed with field name.
+// Obj should be a struct or a pointer to struct, unknown and unexported fields are reported as failures.
+type FieldRule func(obj interface{}) NamedCheck
+
+// NonEmptyRule creates rule that verifies that string field isn't empty.
+func NonEmptyRule(fieldName string) FieldRule {
+	return stringFieldRule(fieldName, func(v *Verify, value string) {
+		v.That(value != "", "should not be empty")
+	})
+}
+
+// EmailRule creates rule that verifies that string field is a valid email, see FormatEmail.
+func EmailRule(fieldName string) FieldRule {
+	return stringFieldRule(fieldName, func(v *Verify, value string) {
+		Format(v, value, FormatEmail, "should be valid")
+	})
+}
+
+// MinLenRule creates rule that verifies that string field is at least n characters (runes) long.
+func MinLenRule(fieldName string, n int) FieldRule {
+	return stringFieldRule(fieldName, func(v *Verify, value string) {
+		v.That(utf8.RuneCountInString(value) >= n, "should be at least %d characters long", n)
+	})
+}
+
+// ApplyRules performs rules on struct obj in order, same as RunChecks.
+// After the first failed verification other rules won't run.
+func (v *Verify) ApplyRules(obj interface{}, rules ...FieldRule) *Verify {
+	checks := make([]NamedCheck, 0, len(rules))
+	for _, rule := range rules {
+		checks = append(checks, rule(obj))
+	}
+	return v.RunChecks(checks...)
+}
+
+// stringFieldRule creates field rule that extracts value of string field and verifies it using verify.
+func stringFieldRule(fieldName string, verify func(v *Verify, value string)) FieldRule {
+	return func(obj interface{}) NamedCheck {
+		return NamedCheck{
+			Name: fieldName,
+			Fn: func(v *Verify) {
+				field, problem := exportedField(obj, fieldName)
+				if problem == "" && field.Kind() != reflect.String {
+					problem = fmt.Sprintf("field %s of %T is not a string", fieldName, obj)
+				}
+				if problem != "" {
+					v.That(false, "%s", problem)
+					return
+				}
+				verify(v, field.String())
+			},
+		}
+	}
+}
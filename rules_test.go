@@ -0,0 +1,72 @@
+package verifier_test
+
+import (
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+)
+
+type signUpDTO struct {
+	Name  string
+	Email string
+	Age   int
+}
+
+var signUpRules = []verifier.FieldRule{
+	verifier.NonEmptyRule("Name"),
+	verifier.MinLenRule("Name", 2),
+	verifier.NonEmptyRule("Email"),
+	verifier.EmailRule("Email"),
+}
+
+func TestVerifier_ApplyRules(t *testing.T) {
+	verify := verifier.New()
+	verify.ApplyRules(signUpDTO{Name: "Jo", Email: "jo@example.com"}, signUpRules...)
+	verify.ApplyRules(&signUpDTO{Name: "Марія", Email: "maria@example.com"}, signUpRules...)
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := []struct {
+		dto     signUpDTO
+		message string
+	}{
+		{signUpDTO{Email: "jo@example.com"}, "Name: should not be empty"},
+		{signUpDTO{Name: "J", Email: "jo@example.com"}, "Name: should be at least 2 characters long"},
+		{signUpDTO{Name: "Jo", Email: "jo"}, `Email: should be valid: "jo" is not a valid email`},
+	}
+	for _, testCase := range testCases {
+		verify := verifier.New()
+		verify.ApplyRules(testCase.dto, signUpRules...)
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %+v", testCase.dto)
+		}
+		if verify.GetError().Error() != testCase.message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
+
+func TestRule_RunChecks(t *testing.T) {
+	dto := signUpDTO{Name: "Jo", Email: "jo@example.com", Age: 30}
+	verify := verifier.New()
+	verify.RunChecks(verifier.NonEmptyRule("Name")(dto), verifier.EmailRule("Email")(dto))
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := map[string]verifier.FieldRule{
+		"Age: field Age of verifier_test.signUpDTO is not a string": verifier.NonEmptyRule("Age"),
+		"Phone: verifier_test.signUpDTO has no field Phone":         verifier.MinLenRule("Phone", 10),
+	}
+	for message, rule := range testCases {
+		verify := verifier.New()
+		verify.RunChecks(rule(dto))
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %s", message)
+		}
+		if verify.GetError().Error() != message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package verifier
+
+import "runtime"
+
+const allocsMeasurementRuns = 10
+
+// AllocsLessThan verifies that fn allocates no more than maxAllocs times per call.
+// It mimics testing.AllocsPerRun: fn is called once to warm up,
+// then average number of heap allocations is measured over several calls using runtime memory statistics.
+// Measurement is approximate, because it counts allocations made by other goroutines as well,
+// so this check is best used in tests and benchmarks.
+// On failure, message is followed by measured number of allocations.
+func (v *Verify) AllocsLessThan(fn func(), maxAllocs uint64, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		allocs := allocsPerRun(fn)
+		if allocs <= maxAllocs {
+			return nil
+		}
+		return v.errorfWithDetail(message, args, "%d allocations per run", allocs)
+	})
+}
+
+func allocsPerRun(fn func()) uint64 {
+	fn()
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	mallocs := memStats.Mallocs
+	for i := 0; i < allocsMeasurementRuns; i++ {
+		fn()
+	}
+	runtime.ReadMemStats(&memStats)
+	return (memStats.Mallocs - mallocs) / allocsMeasurementRuns
+}
+
+// NoGoroutineLeak verifies that number of goroutines hasn't grown above baseline,
+// captured earlier using runtime.NumGoroutine.
+// Goroutines finish asynchronously, so this check is inherently flaky:
+// give finished goroutines a small settle delay before performing it, e.g. at the end of tests.
+// On failure, message is followed by actual number of goroutines.
+func (v *Verify) NoGoroutineLeak(baseline int, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		current := runtime.NumGoroutine()
+		if current <= baseline {
+			return nil
+		}
+		return v.errorfWithDetail(message, args, "%d goroutines running, baseline is %d", current, baseline)
+	})
+}
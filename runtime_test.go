@@ -0,0 +1,63 @@
+package verifier_test
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/storozhukBM/verifier"
+)
+
+var allocsSink []byte
+
+func TestVerifier_AllocsLessThan(t *testing.T) {
+	counter := 0
+	verify := verifier.New()
+	verify.AllocsLessThan(func() { counter++ }, 0, "hot path should not allocate")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verify.AllocsLessThan(func() {
+		for i := 0; i < 3; i++ {
+			allocsSink = make([]byte, 64)
+		}
+	}, 1, "hot path should not allocate")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if !strings.HasPrefix(verify.GetError().Error(), "hot path should not allocate: ") {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestVerifier_NoGoroutineLeak(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-stop
+	}()
+
+	verify := verifier.New()
+	verify.NoGoroutineLeak(baseline, "worker leaked")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	expected := fmt.Sprintf("worker leaked: %d goroutines running, baseline is %d", baseline+1, baseline)
+	if verify.GetError().Error() != expected {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	close(stop)
+	<-stopped
+	time.Sleep(10 * time.Millisecond)
+	verify = verifier.New()
+	verify.NoGoroutineLeak(baseline, "worker leaked")
+	if verify.GetError() != nil {
+		t.Errorf("verifier should be empty: %s", verify.GetError())
+	}
+}
@@ -0,0 +1,76 @@
+package verifier
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+// Severity is a level of check importance.
+type Severity int
+
+const (
+	// SeverityError is a severity of regular checks, failures of which are verification errors.
+	SeverityError Severity = iota
+	// SeverityWarning is a severity of checks, failures of which are worth attention, but not errors.
+	SeverityWarning
+	// SeverityInfo is a severity of purely informational checks.
+	SeverityInfo
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "ERROR"
+	case SeverityWarning:
+		return "WARNING"
+	case SeverityInfo:
+		return "INFO"
+	default:
+		return "Severity(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// ThatSeverity verifies condition passed as second argument with specified severity.
+// Checks of SeverityError are the same as That.
+// Failures of lower severities don't affect verification error,
+// their messages are tracked separately and can be retrieved using Verify.BySeverity.
+// Checks of lower severities are performed even after verification error, so they ignore deadline set by WithDeadline.
+// They are counted by TotalChecksEvaluated, but not recorded by verifiers created by NewRecording,
+// because recorded checks describe verification error only.
+// Details attached using WithDetail are discarded by checks of lower severities, same as by passed checks.
+func (v *Verify) ThatSeverity(sev Severity, positiveCondition bool, message string, args ...interface{}) *Verify {
+	if sev == SeverityError {
+		return v.That(positiveCondition, message, args...)
+	}
+	vObj := v
+	if v == nil {
+		vObj = &Verify{}
+	}
+	vObj.checked = false
+	vObj.details = nil
+	atomic.AddInt64(&totalChecksEvaluated, 1)
+	if positiveCondition {
+		return vObj
+	}
+	if vObj.findings == nil {
+		vObj.findings = make(map[Severity][]string)
+	}
+	vObj.findings[sev] = append(vObj.findings[sev], fmt.Sprintf(message, args...))
+	return vObj
+}
+
+// BySeverity returns messages of failed checks of specified severity in order of their failure.
+// For SeverityError it contains verification error message, if any.
+func (v *Verify) BySeverity(sev Severity) []string {
+	if v == nil {
+		return nil
+	}
+	if sev == SeverityError {
+		if v.err == nil {
+			return nil
+		}
+		return []string{v.err.Error()}
+	}
+	return append([]string(nil), v.findings[sev]...)
+}
@@ -0,0 +1,82 @@
+package verifier_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+)
+
+func TestVerifier_ThatSeverity(t *testing.T) {
+	verify := verifier.New()
+	verify.ThatSeverity(verifier.SeverityWarning, false, "password is weak")
+	verify.ThatSeverity(verifier.SeverityInfo, false, "%d login attempts", 3)
+	verify.ThatSeverity(verifier.SeverityError, true, "user can't be nil")
+	if verify.GetError() != nil {
+		t.Fatalf("lower severities should not affect verification error: %s", verify.GetError())
+	}
+
+	verify.ThatSeverity(verifier.SeverityError, false, "user is blocked")
+	verify.ThatSeverity(verifier.SeverityWarning, false, "email is not confirmed")
+	verify.ThatSeverity(verifier.SeverityWarning, true, "phone is not confirmed")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "user is blocked" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	expected := map[verifier.Severity][]string{
+		verifier.SeverityError:   {"user is blocked"},
+		verifier.SeverityWarning: {"password is weak", "email is not confirmed"},
+		verifier.SeverityInfo:    {"3 login attempts"},
+	}
+	for sev, messages := range expected {
+		if !reflect.DeepEqual(verify.BySeverity(sev), messages) {
+			t.Errorf("unexpected %s messages: %q", sev, verify.BySeverity(sev))
+		}
+	}
+}
+
+func TestVerifier_BySeverity_empty(t *testing.T) {
+	verify := verifier.New()
+	verify.ThatSeverity(verifier.SeverityWarning, true, "password is weak")
+	if verify.GetError() != nil {
+		t.Fatal("verifier should be empty")
+	}
+	for _, sev := range []verifier.Severity{verifier.SeverityError, verifier.SeverityWarning, verifier.SeverityInfo} {
+		if len(verify.BySeverity(sev)) != 0 {
+			t.Errorf("unexpected %s messages: %q", sev, verify.BySeverity(sev))
+		}
+	}
+}
+
+func TestVerifier_ThatSeverity_discards_details(t *testing.T) {
+	verify := verifier.New()
+	verify.WithDetail("field", "amount").ThatSeverity(verifier.SeverityWarning, false, "amount is unusually large")
+	verify.That(false, "currency should be set")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	var detailed *verifier.DetailedError
+	if errors.As(verify.GetError(), &detailed) {
+		t.Errorf("details should be discarded by warning check: %v", detailed.Details)
+	}
+}
+
+func TestVerifier_ThatSeverity_counted(t *testing.T) {
+	before := verifier.TotalChecksEvaluated()
+	verify := verifier.NewRecording()
+	verify.ThatSeverity(verifier.SeverityWarning, false, "amount is unusually large")
+	verify.ThatSeverity(verifier.SeverityInfo, true, "amount is rounded")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+	if evaluated := verifier.TotalChecksEvaluated() - before; evaluated != 2 {
+		t.Errorf("unexpected number of evaluated checks: %d", evaluated)
+	}
+	if len(verify.Record()) != 0 {
+		t.Errorf("checks of lower severities should not be recorded: %v", verify.Record())
+	}
+}
@@ -0,0 +1,27 @@
+//go:build go1.21
+
+package verifier
+
+import "log/slog"
+
+// LogValue implements slog.LogValuer, so verifier can be passed to structured logger directly,
+// e.g. `logger.Info("validated", "result", verify)`.
+// It's a group of "status", which is either "success" or "failure", "error" message on failure
+// and messages of failed SeverityWarning checks as "warnings", if there are any,
+// followed by fields attached using WithContextFields in order of their names.
+func (v *Verify) LogValue() slog.Value {
+	if v == nil {
+		return slog.StringValue("nil")
+	}
+	attrs := []slog.Attr{slog.String("status", "success")}
+	if v.err != nil {
+		attrs = []slog.Attr{slog.String("status", "failure"), slog.String("error", v.err.Error())}
+	}
+	if warnings := v.BySeverity(SeverityWarning); len(warnings) > 0 {
+		attrs = append(attrs, slog.Any("warnings", warnings))
+	}
+	for _, key := range sortedFieldNames(v.fields) {
+		attrs = append(attrs, slog.Any(key, v.fields[key]))
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,87 @@
+//go:build go1.21
+
+package verifier_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+)
+
+func TestVerifier_LogValue(t *testing.T) {
+	testCases := map[string]struct {
+		verify   func(v *verifier.Verify)
+		expected string
+	}{
+		"success": {
+			verify: func(v *verifier.Verify) {
+				v.That(true, "amount should be positive")
+			},
+			expected: "result.status=success",
+		},
+		"failure": {
+			verify: func(v *verifier.Verify) {
+				v.That(false, "amount should be positive")
+			},
+			expected: `result.status=failure result.error="amount should be positive"`,
+		},
+		"failure with warnings": {
+			verify: func(v *verifier.Verify) {
+				v.That(false, "amount should be positive")
+				v.ThatSeverity(verifier.SeverityWarning, false, "currency is deprecated")
+			},
+			expected: `result.status=failure result.error="amount should be positive" result.warnings="[currency is deprecated]"`,
+		},
+	}
+	for name, testCase := range testCases {
+		verify := verifier.New()
+		testCase.verify(verify)
+		output := &bytes.Buffer{}
+		logger := slog.New(slog.NewTextHandler(output, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+				if len(groups) == 0 && attr.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return attr
+			},
+		}))
+		logger.Info("validated", "result", verify)
+		expected := "level=INFO msg=validated " + testCase.expected
+		if strings.TrimSpace(output.String()) != expected {
+			t.Errorf("unexpected log output for %s: %s", name, output)
+		}
+		verify.GetError()
+	}
+}
+
+func TestVerifier_LogValue_group(t *testing.T) {
+	verify := verifier.New().That(false, "amount should be positive")
+	value := verify.LogValue()
+	if value.Kind() != slog.KindGroup {
+		t.Fatalf("unexpected value kind: %s", value.Kind())
+	}
+	attrs := value.Group()
+	if len(attrs) != 2 || attrs[0].Key != "status" || attrs[1].Key != "error" {
+		t.Errorf("unexpected group: %v", attrs)
+	}
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+}
+
+func TestVerifier_LogValue_context_fields(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextKey("userID"), 42)
+	ctx = context.WithValue(ctx, contextKey("traceID"), "4bf92f35")
+	verify := verifier.New().WithContextFields(ctx, contextKey("userID"), contextKey("traceID"))
+	attrs := verify.LogValue().Group()
+	if len(attrs) != 3 || attrs[1].Key != "traceID" || attrs[2].Key != "userID" {
+		t.Errorf("unexpected group: %v", attrs)
+	}
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+}
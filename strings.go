@@ -0,0 +1,223 @@
+package verifier
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OneOfFold verifies that value is equal to one of allowed strings,
+// compared case-insensitively using `strings.EqualFold`.
+// If allowed set is empty, verification fails.
+func OneOfFold(v *Verify, value string, allowed []string, message string, args ...interface{}) *Verify {
+	return v.Predicate(func() bool {
+		for _, a := range allowed {
+			if strings.EqualFold(value, a) {
+				return true
+			}
+		}
+		return false
+	}, message, args...)
+}
+
+// StringerEquals verifies that string representation of obj is equal to expected.
+// Nil obj, including typed nil pointer, fails without calling its String method.
+// On failure, message is followed by actual string representation.
+func (v *Verify) StringerEquals(obj fmt.Stringer, expected string, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if obj == nil {
+			return v.errorfWithDetail(message, args, "stringer is nil")
+		}
+		if value := reflect.ValueOf(obj); value.Kind() == reflect.Ptr && value.IsNil() {
+			return v.errorfWithDetail(message, args, "stringer is nil %T", obj)
+		}
+		if actual := obj.String(); actual != expected {
+			return v.errorfWithDetail(message, args, "string is %q", actual)
+		}
+		return nil
+	})
+}
+
+// ContainsNone verifies that s contains none of substrs.
+// On failure, message is followed by the first found substring.
+// Empty list of substrings always passes.
+func ContainsNone(v *Verify, s string, substrs []string, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		for _, substr := range substrs {
+			if strings.Contains(s, substr) {
+				return v.errorfWithDetail(message, args, "contains %q", substr)
+			}
+		}
+		return nil
+	})
+}
+
+// IsLower verifies that s is in lower case, meaning `s == strings.ToLower(s)`.
+// Strings without cased characters, like digits, pass.
+func IsLower(v *Verify, s string, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if s == strings.ToLower(s) {
+			return nil
+		}
+		return v.errorf(message, args...)
+	})
+}
+
+// IsUpper verifies that s is in upper case, meaning `s == strings.ToUpper(s)`.
+// Strings without cased characters, like digits, pass.
+func IsUpper(v *Verify, s string, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if s == strings.ToUpper(s) {
+			return nil
+		}
+		return v.errorf(message, args...)
+	})
+}
+
+// BalancedDelimiters verifies that open and close delimiters are balanced in s.
+// On failure, message is followed by byte position of the first imbalance:
+// either unexpected close delimiter or unclosed open one.
+func BalancedDelimiters(v *Verify, s string, open, close rune, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		var openPositions []int
+		for i, r := range s {
+			switch {
+			case r == close && len(openPositions) > 0:
+				openPositions = openPositions[:len(openPositions)-1]
+			case r == open:
+				openPositions = append(openPositions, i)
+			case r == close:
+				return v.errorfWithDetail(message, args, "unexpected %q at position %d", close, i)
+			}
+		}
+		if len(openPositions) > 0 {
+			return v.errorfWithDetail(message, args, "unclosed %q at position %d", open, openPositions[0])
+		}
+		return nil
+	})
+}
+
+// StringFormat is a well known string format, that can be verified using Format.
+type StringFormat int
+
+const (
+	// FormatEmail is a bare email address, like "john@example.com", without display name.
+	FormatEmail StringFormat = iota + 1
+	// FormatUUID is a UUID in canonical textual representation, like "123e4567-e89b-12d3-a456-426614174000".
+	FormatUUID
+	// FormatDateRFC3339 is a date and time in RFC 3339 format, like "2006-01-02T15:04:05Z".
+	FormatDateRFC3339
+	// FormatHostname is a host name as defined in RFC 1123, like "api.example.com".
+	FormatHostname
+)
+
+var (
+	uuidRegexp     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hostnameRegexp = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+)
+
+var stringFormats = map[StringFormat]struct {
+	name  string
+	valid func(string) bool
+}{
+	FormatEmail: {name: "email", valid: func(s string) bool {
+		address, err := mail.ParseAddress(s)
+		return err == nil && address.Address == s
+	}},
+	FormatUUID: {name: "UUID", valid: uuidRegexp.MatchString},
+	FormatDateRFC3339: {name: "RFC 3339 date", valid: func(s string) bool {
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	}},
+	FormatHostname: {name: "hostname", valid: func(s string) bool {
+		return len(s) <= 253 && hostnameRegexp.MatchString(s)
+	}},
+}
+
+func (f StringFormat) String() string {
+	format, ok := stringFormats[f]
+	if !ok {
+		return "StringFormat(" + strconv.Itoa(int(f)) + ")"
+	}
+	return format.name
+}
+
+// Format verifies that value is a valid string of specified well known format.
+// On failure, message is followed by the value and the format name.
+// Unknown format is reported as failure.
+func Format(v *Verify, value string, format StringFormat, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		f, ok := stringFormats[format]
+		if !ok {
+			return v.errorfWithDetail(message, args, "unknown format %v", format)
+		}
+		if !f.valid(value) {
+			return v.errorfWithDetail(message, args, "%q is not a valid %s", value, f.name)
+		}
+		return nil
+	})
+}
+
+// ValidBase64 verifies that s is decodable using standard padded base64 encoding (base64.StdEncoding).
+// Empty string is considered valid, because it's an encoding of empty input.
+// On failure, message is followed by decoding error.
+func ValidBase64(v *Verify, s string, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if _, err := base64.StdEncoding.DecodeString(s); err != nil {
+			return v.errorfWithDetail(message, args, "%v", err)
+		}
+		return nil
+	})
+}
+
+// ValidHex verifies that s is decodable as hexadecimal string, in either case.
+// Empty string is considered valid, because it's an encoding of empty input.
+// On failure, message is followed by decoding error.
+func ValidHex(v *Verify, s string, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if _, err := hex.DecodeString(s); err != nil {
+			return v.errorfWithDetail(message, args, "%v", err)
+		}
+		return nil
+	})
+}
+
+// MatchesAny verifies that value matches at least one of regular expressions patterns,
+// e.g. to accept input in several formats.
+// All patterns are compiled before matching, so invalid pattern is reported as failure even if other pattern matches.
+// On failure, message is followed by the value or by description of invalid pattern.
+func MatchesAny(v *Verify, value string, patterns []string, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		compiled := make([]*regexp.Regexp, 0, len(patterns))
+		for _, pattern := range patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return v.errorfWithDetail(message, args, "invalid pattern %q: %v", pattern, err)
+			}
+			compiled = append(compiled, re)
+		}
+		for _, re := range compiled {
+			if re.MatchString(value) {
+				return nil
+			}
+		}
+		return v.errorfWithDetail(message, args, "%q doesn't match any pattern", value)
+	})
+}
+
+// ByteLenAtMost verifies that length of s in bytes, not runes, is at most max, e.g. to fit database column.
+// On failure, message is followed by actual byte length.
+func ByteLenAtMost(v *Verify, s string, max int, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if len(s) <= max {
+			return nil
+		}
+		return v.errorfWithDetail(message, args, "byte length is %d, max is %d", len(s), max)
+	})
+}
@@ -0,0 +1,293 @@
+package verifier_test
+
+import (
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+)
+
+func TestOneOfFold(t *testing.T) {
+	methods := []string{"GET", "POST", "PUT"}
+
+	verify := verifier.New()
+	verifier.OneOfFold(verify, "post", methods, "unsupported method")
+	verifier.OneOfFold(verify, "Get", methods, "unsupported method")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.OneOfFold(verify, "DELETE", methods, "unsupported method: %s", "DELETE")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unsupported method: DELETE" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.OneOfFold(verify, "", nil, "empty allowed set")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+}
+
+func TestBalancedDelimiters(t *testing.T) {
+	verify := verifier.New()
+	verifier.BalancedDelimiters(verify, "Hello, {name}!", '{', '}', "invalid template")
+	verifier.BalancedDelimiters(verify, "{{a}{b{c}}}", '{', '}', "invalid template")
+	verifier.BalancedDelimiters(verify, "", '(', ')', "invalid expression")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.BalancedDelimiters(verify, "(a + (b * c)", '(', ')', "invalid expression")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "invalid expression: unclosed '(' at position 0" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.BalancedDelimiters(verify, "(a + b)) * c", '(', ')', "invalid %s", "expression")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "invalid expression: unexpected ')' at position 7" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestFormat(t *testing.T) {
+	testCases := []struct {
+		format  verifier.StringFormat
+		valid   string
+		invalid string
+		message string
+	}{
+		{verifier.FormatEmail, "john@example.com", "John <john@example.com>", `invalid input: "John <john@example.com>" is not a valid email`},
+		{verifier.FormatUUID, "123e4567-e89b-12d3-a456-426614174000", "123e4567-e89b-12d3-a456", `invalid input: "123e4567-e89b-12d3-a456" is not a valid UUID`},
+		{verifier.FormatDateRFC3339, "2006-01-02T15:04:05+07:00", "2006-01-02", `invalid input: "2006-01-02" is not a valid RFC 3339 date`},
+		{verifier.FormatHostname, "api.example.com", "api_example.com", `invalid input: "api_example.com" is not a valid hostname`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.format.String(), func(t *testing.T) {
+			verify := verifier.New()
+			verifier.Format(verify, testCase.valid, testCase.format, "invalid input")
+			if verify.GetError() != nil {
+				t.Fatalf("verifier should be empty: %s", verify.GetError())
+			}
+			verifier.Format(verify, testCase.invalid, testCase.format, "invalid input")
+			if verify.GetError() == nil {
+				t.Fatal("verifier should be filled")
+			}
+			if verify.GetError().Error() != testCase.message {
+				t.Errorf("unexpected error message: %s", verify.GetError())
+			}
+		})
+	}
+}
+
+func TestFormat_unknown(t *testing.T) {
+	verify := verifier.New()
+	verifier.Format(verify, "john@example.com", verifier.StringFormat(42), "invalid input")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "invalid input: unknown format StringFormat(42)" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestContainsNone(t *testing.T) {
+	forbidden := []string{"<script", "javascript:", "onerror="}
+	verify := verifier.New()
+	verifier.ContainsNone(verify, "Hello, <b>world</b>", forbidden, "unsafe input")
+	verifier.ContainsNone(verify, "<script>", nil, "unsafe input")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.ContainsNone(verify, `<img src=x onerror="javascript:alert(1)">`, forbidden, "unsafe %s", "input")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != `unsafe input: contains "javascript:"` {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+type color int
+
+func (c color) String() string {
+	return [...]string{"red", "green", "blue"}[c]
+}
+
+type named struct {
+	name string
+}
+
+func (n *named) String() string {
+	return n.name
+}
+
+func TestVerifier_StringerEquals(t *testing.T) {
+	verify := verifier.New()
+	verify.StringerEquals(color(1), "green", "unexpected color name")
+	verify.StringerEquals(&named{name: "John"}, "John", "unexpected name")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verify.StringerEquals(color(2), "green", "unexpected %s name", "color")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != `unexpected color name: string is "blue"` {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verify.StringerEquals(nil, "", "unexpected name")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected name: stringer is nil" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	var nilNamed *named
+	verify = verifier.New()
+	verify.StringerEquals(nilNamed, "", "unexpected name")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected name: stringer is nil *verifier_test.named" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestIsLower(t *testing.T) {
+	verify := verifier.New()
+	verifier.IsLower(verify, "user_id", "identifier should be normalized")
+	verifier.IsLower(verify, "42-17", "identifier should be normalized")
+	verifier.IsLower(verify, "", "identifier should be normalized")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.IsLower(verify, "userID", "identifier %q should be normalized", "userID")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != `identifier "userID" should be normalized` {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestIsUpper(t *testing.T) {
+	verify := verifier.New()
+	verifier.IsUpper(verify, "USD", "currency code should be normalized")
+	verifier.IsUpper(verify, "978", "currency code should be normalized")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.IsUpper(verify, "Usd", "currency code should be normalized")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "currency code should be normalized" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestValidBase64(t *testing.T) {
+	verify := verifier.New()
+	verifier.ValidBase64(verify, "c2lnbmF0dXJl", "token should be encoded")
+	verifier.ValidBase64(verify, "dG9rZW4=", "token should be encoded")
+	verifier.ValidBase64(verify, "", "token should be encoded")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.ValidBase64(verify, "dG9rZW4", "%s should be encoded", "token")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "token should be encoded: illegal base64 data at input byte 4" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestValidHex(t *testing.T) {
+	verify := verifier.New()
+	verifier.ValidHex(verify, "deadBEEF", "signature should be encoded")
+	verifier.ValidHex(verify, "", "signature should be encoded")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := map[string]string{
+		"abc": "signature should be encoded: encoding/hex: odd length hex string",
+		"zz":  "signature should be encoded: encoding/hex: invalid byte: U+007A 'z'",
+	}
+	for value, message := range testCases {
+		verify := verifier.New()
+		verifier.ValidHex(verify, value, "%s should be encoded", "signature")
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %q", value)
+		}
+		if verify.GetError().Error() != message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
+
+func TestMatchesAny(t *testing.T) {
+	phoneFormats := []string{`^\+\d{11,12}$`, `^\(\d{3}\) \d{3}-\d{4}$`}
+	verify := verifier.New()
+	verifier.MatchesAny(verify, "+380441234567", phoneFormats, "unsupported phone format")
+	verifier.MatchesAny(verify, "(212) 555-0100", phoneFormats, "unsupported phone format")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := []struct {
+		patterns []string
+		message  string
+	}{
+		{phoneFormats, `unsupported phone format: "555-0100" doesn't match any pattern`},
+		{nil, `unsupported phone format: "555-0100" doesn't match any pattern`},
+		{[]string{`^\d{3}-\d{4}$`, `^(\d{3}$`}, "unsupported phone format: invalid pattern \"^(\\\\d{3}$\": " +
+			"error parsing regexp: missing closing ): `^(\\d{3}$`"},
+	}
+	for _, testCase := range testCases {
+		verify := verifier.New()
+		verifier.MatchesAny(verify, "555-0100", testCase.patterns, "unsupported %s format", "phone")
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %q", testCase.patterns)
+		}
+		if verify.GetError().Error() != testCase.message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
+
+func TestByteLenAtMost(t *testing.T) {
+	verify := verifier.New()
+	verifier.ByteLenAtMost(verify, "Kyiv", 4, "city should fit column")
+	verifier.ByteLenAtMost(verify, "", 0, "city should fit column")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.ByteLenAtMost(verify, "Київ", 4, "%s should fit column", "city")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "city should fit column: byte length is 8, max is 4" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
@@ -0,0 +1,348 @@
+package verifier
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// NoNilPointers verifies that none of exported pointer, slice or map fields of struct obj is nil.
+// Obj should be a struct or a pointer to struct.
+// Fields of nested structs, both embedded by value and referenced by non-nil pointer,
+// are verified as well, but only one level deep.
+// On failure, message is followed by the names of all nil fields.
+func NoNilPointers(v *Verify, obj interface{}, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		value, ok := structValue(obj)
+		if !ok {
+			return v.errorfWithDetail(message, args, "%T is not a struct", obj)
+		}
+		fields := findNilFields(value, 1)
+		switch len(fields) {
+		case 0:
+			return nil
+		case 1:
+			return v.errorfWithDetail(message, args, "%s is nil", fields[0])
+		default:
+			return v.errorfWithDetail(message, args, "%s are nil", strings.Join(fields, ", "))
+		}
+	})
+}
+
+// FieldsMatch verifies fields of struct obj using validators, mapped by field names.
+// Validator receives field value and returns whether it's valid and failure message if not.
+// Fields are verified in order of their names, failure message is prefixed with field name.
+// Unknown and unexported fields are reported as failures.
+// After the first failed verification other fields won't be verified.
+func (v *Verify) FieldsMatch(obj interface{}, validators map[string]func(interface{}) (bool, string)) *Verify {
+	names := make([]string, 0, len(validators))
+	for name := range validators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		name, validator := name, validators[name]
+		v = v.check("%s", []interface{}{name}, func(v *Verify) error {
+			field, problem := exportedField(obj, name)
+			if problem != "" {
+				return v.errorf("%s", problem)
+			}
+			ok, message := validator(field.Interface())
+			if !ok {
+				return v.errorf("%s: %s", name, message)
+			}
+			return nil
+		})
+	}
+	return v
+}
+
+// HasJSONTag verifies that field of struct obj has `json` tag and isn't excluded from JSON using "-".
+// Obj should be a struct or a pointer to struct.
+// On failure, message is followed by description of the problem.
+func HasJSONTag(v *Verify, obj interface{}, fieldName string, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		value, ok := structValue(obj)
+		if !ok {
+			return v.errorfWithDetail(message, args, "%T is not a struct", obj)
+		}
+		field, ok := value.Type().FieldByName(fieldName)
+		if !ok {
+			return v.errorfWithDetail(message, args, "%T has no field %s", obj, fieldName)
+		}
+		tag, ok := field.Tag.Lookup("json")
+		if !ok {
+			return v.errorfWithDetail(message, args, "field %s has no json tag", fieldName)
+		}
+		if tag == "-" {
+			return v.errorfWithDetail(message, args, "field %s is excluded from JSON", fieldName)
+		}
+		return nil
+	})
+}
+
+// FieldEquals verifies that field of struct obj is deeply equal to expected.
+// Obj should be a struct or a pointer to struct, unknown and unexported fields are reported as failures.
+// On failure, message is followed by the field name and its actual value.
+func FieldEquals(v *Verify, obj interface{}, fieldName string, expected interface{}, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		field, problem := exportedField(obj, fieldName)
+		if problem != "" {
+			return v.errorfWithDetail(message, args, "%s", problem)
+		}
+		if actual := field.Interface(); !reflect.DeepEqual(actual, expected) {
+			return v.errorfWithDetail(message, args, "field %s is %v, expected %v", fieldName, actual, expected)
+		}
+		return nil
+	})
+}
+
+// EqualExcept verifies that structs actual and expected are deeply equal, ignoring fields named in ignoreFields,
+// e.g. generated IDs and timestamps of persisted objects.
+// Actual and expected should be structs or pointers to structs of the same type,
+// ignored fields should be exported. Neither actual nor expected is modified.
+// On failure, message is followed by the name of the first differing field and both of its values.
+func EqualExcept(v *Verify, actual, expected interface{}, ignoreFields []string, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		actualValue, expectedValue, problem := sameTypeStructs(actual, expected)
+		if problem != "" {
+			return v.errorfWithDetail(message, args, "%s", problem)
+		}
+		paths := make([][]int, 0, len(ignoreFields))
+		for _, name := range ignoreFields {
+			field, problem := exportedFieldType(actual, name)
+			if problem != "" {
+				return v.errorfWithDetail(message, args, "%s", problem)
+			}
+			paths = append(paths, field.Index)
+		}
+		if difference, problem := structDifference(actualValue, expectedValue, paths); problem != "" {
+			return v.errorfWithDetail(message, args, "%s", problem)
+		} else if difference != "" {
+			return v.errorfWithDetail(message, args, "%s", difference)
+		}
+		return nil
+	})
+}
+
+// EqualWithTolerance verifies that structs actual and expected are deeply equal,
+// except float fields named in fieldTolerances, that may differ by no more than their tolerance.
+// Actual and expected should be structs or pointers to structs of the same type,
+// fields with tolerance should be exported and have float type. Neither actual nor expected is modified.
+// On failure, message is followed by the name of the first differing field and description of the difference.
+func EqualWithTolerance(
+	v *Verify, actual, expected interface{}, fieldTolerances map[string]float64, message string, args ...interface{},
+) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		actualValue, expectedValue, problem := sameTypeStructs(actual, expected)
+		if problem != "" {
+			return v.errorfWithDetail(message, args, "%s", problem)
+		}
+		names := make([]string, 0, len(fieldTolerances))
+		for name := range fieldTolerances {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		paths := make([][]int, 0, len(names))
+		for _, name := range names {
+			field, problem := exportedFieldType(actual, name)
+			if problem != "" {
+				return v.errorfWithDetail(message, args, "%s", problem)
+			}
+			if kind := field.Type.Kind(); kind != reflect.Float32 && kind != reflect.Float64 {
+				return v.errorfWithDetail(message, args, "field %s of %T is not a float", name, actual)
+			}
+			paths = append(paths, field.Index)
+			actualField, actualErr := actualValue.FieldByIndexErr(field.Index)
+			expectedField, expectedErr := expectedValue.FieldByIndexErr(field.Index)
+			if actualErr != nil || expectedErr != nil {
+				// field is behind nil embedded pointer, the pointers are compared below
+				continue
+			}
+			diff := math.Abs(actualField.Float() - expectedField.Float())
+			if !(diff <= fieldTolerances[name]) {
+				return v.errorfWithDetail(
+					message, args, "field %s differs: %v != %v, tolerance is %v",
+					name, actualField.Interface(), expectedField.Interface(), fieldTolerances[name],
+				)
+			}
+		}
+		if difference, problem := structDifference(actualValue, expectedValue, paths); problem != "" {
+			return v.errorfWithDetail(message, args, "%s", problem)
+		} else if difference != "" {
+			return v.errorfWithDetail(message, args, "%s", difference)
+		}
+		return nil
+	})
+}
+
+// sameTypeStructs returns struct values of actual and expected of the same type,
+// or description of the problem why it can't.
+func sameTypeStructs(actual, expected interface{}) (reflect.Value, reflect.Value, string) {
+	actualValue, ok := structValue(actual)
+	if !ok {
+		return reflect.Value{}, reflect.Value{}, fmt.Sprintf("%T is not a struct", actual)
+	}
+	expectedValue, ok := structValue(expected)
+	if !ok {
+		return reflect.Value{}, reflect.Value{}, fmt.Sprintf("%T is not a struct", expected)
+	}
+	if actualValue.Type() != expectedValue.Type() {
+		return reflect.Value{}, reflect.Value{}, fmt.Sprintf("%T and %T are different types", actual, expected)
+	}
+	return actualValue, expectedValue, ""
+}
+
+// withoutFields returns copy of struct value with fields at index paths set to zero values.
+// Structs referenced by embedded pointers along the paths are copied as well, so value is never modified.
+// Fields behind nil embedded pointers are left as is.
+// It returns description of the problem if field can't be excluded.
+func withoutFields(value reflect.Value, paths [][]int) (reflect.Value, string) {
+	result := reflect.New(value.Type()).Elem()
+	result.Set(value)
+	for _, path := range paths {
+		current := result
+		for _, i := range path[:len(path)-1] {
+			field := current.Field(i)
+			if field.Kind() == reflect.Ptr {
+				if field.IsNil() {
+					current = reflect.Value{}
+					break
+				}
+				if !field.CanSet() {
+					return reflect.Value{}, fmt.Sprintf(
+						"field %s is promoted through unexported embedded pointer %s",
+						value.Type().FieldByIndex(path).Name, current.Type().Field(i).Name,
+					)
+				}
+				clone := reflect.New(field.Type().Elem())
+				clone.Elem().Set(field.Elem())
+				field.Set(clone)
+				field = clone.Elem()
+			}
+			current = field
+		}
+		if current.IsValid() {
+			field := current.Field(path[len(path)-1])
+			field.Set(reflect.Zero(field.Type()))
+		}
+	}
+	return result, ""
+}
+
+// structDifference returns description of the first difference between structs of the same type,
+// excluding fields at index paths, or empty string if they are deeply equal.
+// It returns description of the problem if fields can't be excluded.
+func structDifference(actual, expected reflect.Value, excluded [][]int) (string, string) {
+	actual, problem := withoutFields(actual, excluded)
+	if problem != "" {
+		return "", problem
+	}
+	expected, problem = withoutFields(expected, excluded)
+	if problem != "" {
+		return "", problem
+	}
+	if reflect.DeepEqual(actual.Interface(), expected.Interface()) {
+		return "", ""
+	}
+	valueType := actual.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		if valueType.Field(i).PkgPath != "" {
+			continue
+		}
+		actualField, expectedField := actual.Field(i).Interface(), expected.Field(i).Interface()
+		if !reflect.DeepEqual(actualField, expectedField) {
+			return fmt.Sprintf("field %s differs: %v != %v", valueType.Field(i).Name, actualField, expectedField), ""
+		}
+	}
+	return "unexported fields differ", ""
+}
+
+// RequiredIf verifies that value is set, i.e. it's neither nil nor a zero value of its type,
+// but only when condition is true, e.g. card number is required only if payment method is card.
+// When condition is false the check always passes.
+// On failure, error message is "<fieldName> is required".
+func (v *Verify) RequiredIf(condition bool, fieldName string, value interface{}) *Verify {
+	return v.check("%s is required", []interface{}{fieldName}, func(v *Verify) error {
+		if !condition {
+			return nil
+		}
+		reflected := reflect.ValueOf(value)
+		if !reflected.IsValid() || reflected.IsZero() {
+			return v.errorf("%s is required", fieldName)
+		}
+		return nil
+	})
+}
+
+// exportedField returns value of exported field of struct obj, or description of the problem why it can't.
+func exportedField(obj interface{}, name string) (reflect.Value, string) {
+	fieldType, problem := exportedFieldType(obj, name)
+	if problem != "" {
+		return reflect.Value{}, problem
+	}
+	value, _ := structValue(obj)
+	field, err := value.FieldByIndexErr(fieldType.Index)
+	if err != nil {
+		return reflect.Value{}, fmt.Sprintf("field %s of %T can't be accessed: %v", name, obj, err)
+	}
+	return field, ""
+}
+
+// exportedFieldType returns description of exported field of struct obj, or description of the problem why it can't.
+func exportedFieldType(obj interface{}, name string) (reflect.StructField, string) {
+	value, ok := structValue(obj)
+	if !ok {
+		return reflect.StructField{}, fmt.Sprintf("%T is not a struct", obj)
+	}
+	fieldType, ok := value.Type().FieldByName(name)
+	if !ok {
+		return reflect.StructField{}, fmt.Sprintf("%T has no field %s", obj, name)
+	}
+	if fieldType.PkgPath != "" {
+		return reflect.StructField{}, fmt.Sprintf("field %s of %T is unexported", name, obj)
+	}
+	return fieldType, ""
+}
+
+// structValue returns struct value of obj, dereferencing pointer if needed.
+func structValue(obj interface{}) (reflect.Value, bool) {
+	value := reflect.ValueOf(obj)
+	if value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	return value, value.Kind() == reflect.Struct
+}
+
+// findNilFields returns paths of all nil pointer, slice and map fields of struct value, nested up to depth levels.
+func findNilFields(value reflect.Value, depth int) []string {
+	var result []string
+	valueType := value.Type()
+	for i := 0; i < value.NumField(); i++ {
+		fieldType := valueType.Field(i)
+		if fieldType.PkgPath != "" {
+			continue
+		}
+		field := value.Field(i)
+		switch field.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map:
+			if field.IsNil() {
+				result = append(result, fieldType.Name)
+				continue
+			}
+		}
+		if depth == 0 {
+			continue
+		}
+		nested, ok := structValue(field.Interface())
+		if !ok {
+			continue
+		}
+		for _, name := range findNilFields(nested, depth-1) {
+			result = append(result, fieldType.Name+"."+name)
+		}
+	}
+	return result
+}
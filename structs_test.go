@@ -0,0 +1,418 @@
+package verifier_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/storozhukBM/verifier"
+)
+
+type tlsConfig struct {
+	CertFile *string
+	KeyFile  *string
+}
+
+type serverConfig struct {
+	Addr    *string
+	Headers map[string]string
+	Hosts   []string
+	TLS     *tlsConfig
+	Limits  struct{ MaxConns *int }
+	secret  *string
+}
+
+func TestNoNilPointers(t *testing.T) {
+	addr, cert, key, maxConns := ":8080", "cert.pem", "key.pem", 100
+	config := serverConfig{
+		Addr:    &addr,
+		Headers: map[string]string{},
+		Hosts:   []string{},
+		TLS:     &tlsConfig{CertFile: &cert, KeyFile: &key},
+	}
+	config.Limits.MaxConns = &maxConns
+
+	verify := verifier.New()
+	verifier.NoNilPointers(verify, config, "incomplete config")
+	verifier.NoNilPointers(verify, &config, "incomplete config")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	config.Hosts = nil
+	config.TLS.KeyFile = nil
+	verifier.NoNilPointers(verify, config, "incomplete %s", "config")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "incomplete config: Hosts, TLS.KeyFile are nil" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	config.Hosts = []string{"localhost"}
+	verify = verifier.New()
+	verifier.NoNilPointers(verify, config, "incomplete config")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "incomplete config: TLS.KeyFile is nil" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.NoNilPointers(verify, &addr, "incomplete config")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "incomplete config: *string is not a struct" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+type userDTO struct {
+	Name  string
+	Age   int
+	Email string
+	token string
+}
+
+func TestVerifier_FieldsMatch(t *testing.T) {
+	validators := map[string]func(interface{}) (bool, string){
+		"Name": func(value interface{}) (bool, string) {
+			return value.(string) != "", "can't be empty"
+		},
+		"Age": func(value interface{}) (bool, string) {
+			return value.(int) >= 21, "should be 21 or higher"
+		},
+		"Email": func(value interface{}) (bool, string) {
+			return strings.Contains(value.(string), "@"), "should be valid email"
+		},
+	}
+
+	verify := verifier.New()
+	verify.FieldsMatch(userDTO{Name: "John", Age: 42, Email: "john@example.com"}, validators)
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verify.FieldsMatch(&userDTO{Name: "John", Age: 18, Email: "john"}, validators)
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "Age: should be 21 or higher" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestVerifier_FieldsMatch_unknown_field(t *testing.T) {
+	anything := func(interface{}) (bool, string) { return true, "" }
+
+	verify := verifier.New()
+	verify.FieldsMatch(userDTO{}, map[string]func(interface{}) (bool, string){"Phone": anything})
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "verifier_test.userDTO has no field Phone" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verify.FieldsMatch(userDTO{}, map[string]func(interface{}) (bool, string){"token": anything})
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "field token of verifier_test.userDTO is unexported" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestHasJSONTag(t *testing.T) {
+	type accountDTO struct {
+		ID       string `json:"id"`
+		Balance  int64  `json:"balance,omitempty"`
+		Currency string
+		Password string `json:"-"`
+		Owner    string `json:",omitempty"`
+	}
+
+	verify := verifier.New()
+	verifier.HasJSONTag(verify, accountDTO{}, "ID", "account should be serializable")
+	verifier.HasJSONTag(verify, &accountDTO{}, "Balance", "account should be serializable")
+	verifier.HasJSONTag(verify, accountDTO{}, "Owner", "account should be serializable")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := map[string]string{
+		"Currency": "account should be serializable: field Currency has no json tag",
+		"Password": "account should be serializable: field Password is excluded from JSON",
+		"Phone":    "account should be serializable: verifier_test.accountDTO has no field Phone",
+	}
+	for field, message := range testCases {
+		verify := verifier.New()
+		verifier.HasJSONTag(verify, accountDTO{}, field, "account should be %s", "serializable")
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %s", field)
+		}
+		if verify.GetError().Error() != message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
+
+func TestVerify_RequiredIf(t *testing.T) {
+	type payment struct {
+		Method     string
+		CardNumber string
+	}
+
+	cardPayment := payment{Method: "card", CardNumber: "4111111111111111"}
+	cashPayment := payment{Method: "cash"}
+	verify := verifier.New().
+		RequiredIf(cardPayment.Method == "card", "cardNumber", cardPayment.CardNumber).
+		RequiredIf(cashPayment.Method == "card", "cardNumber", cashPayment.CardNumber).
+		RequiredIf(false, "receipt", nil)
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := map[string]interface{}{
+		"empty string": "",
+		"nil":          nil,
+		"nil pointer":  (*payment)(nil),
+	}
+	for name, value := range testCases {
+		verify := verifier.New().RequiredIf(true, "cardNumber", value)
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %s", name)
+		}
+		if verify.GetError().Error() != "cardNumber is required" {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
+
+func TestEqualExcept(t *testing.T) {
+	type order struct {
+		ID        int64
+		Item      string
+		Quantity  int
+		CreatedAt time.Time
+	}
+
+	expected := order{Item: "book", Quantity: 2}
+	persisted := &order{ID: 42, Item: "book", Quantity: 2, CreatedAt: time.Now()}
+	verify := verifier.New()
+	verifier.EqualExcept(verify, persisted, expected, []string{"ID", "CreatedAt"}, "unexpected order")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	persisted.Quantity = 3
+	verifier.EqualExcept(verify, persisted, expected, []string{"ID", "CreatedAt"}, "unexpected %s", "order")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected order: field Quantity differs: 3 != 2" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.EqualExcept(verify, persisted, expected, []string{"UpdatedAt"}, "unexpected order")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected order: *verifier_test.order has no field UpdatedAt" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestEqualWithTolerance(t *testing.T) {
+	type measurement struct {
+		Sample      string
+		Mass        float64
+		Temperature float32
+		Runs        int
+	}
+
+	expected := measurement{Sample: "A-1", Mass: 12.5, Temperature: 21.0, Runs: 3}
+	tolerances := map[string]float64{"Mass": 0.01, "Temperature": 0.5}
+	verify := verifier.New()
+	actual := &measurement{Sample: "A-1", Mass: 12.505, Temperature: 21.25, Runs: 3}
+	verifier.EqualWithTolerance(verify, actual, expected, tolerances, "unexpected measurement")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := []struct {
+		actual     measurement
+		tolerances map[string]float64
+		message    string
+	}{
+		{
+			actual:     measurement{Sample: "A-1", Mass: 12.6, Temperature: 21.0, Runs: 3},
+			tolerances: tolerances,
+			message:    "unexpected measurement: field Mass differs: 12.6 != 12.5, tolerance is 0.01",
+		},
+		{
+			actual:     measurement{Sample: "A-1", Mass: 12.5, Temperature: 21.0, Runs: 4},
+			tolerances: tolerances,
+			message:    "unexpected measurement: field Runs differs: 4 != 3",
+		},
+		{
+			actual:     expected,
+			tolerances: map[string]float64{"Pressure": 0.1},
+			message:    "unexpected measurement: verifier_test.measurement has no field Pressure",
+		},
+		{
+			actual:     expected,
+			tolerances: map[string]float64{"Runs": 1},
+			message:    "unexpected measurement: field Runs of verifier_test.measurement is not a float",
+		},
+	}
+	for _, testCase := range testCases {
+		verify := verifier.New()
+		verifier.EqualWithTolerance(verify, testCase.actual, expected, testCase.tolerances, "unexpected %s", "measurement")
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %+v", testCase.actual)
+		}
+		if verify.GetError().Error() != testCase.message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
+
+func TestFieldEquals(t *testing.T) {
+	type invoice struct {
+		Number string
+		Lines  []string
+		Paid   bool
+		secret string
+	}
+
+	decoded := &invoice{Number: "INV-1", Lines: []string{"book", "pen"}, Paid: true}
+	verify := verifier.New()
+	verifier.FieldEquals(verify, decoded, "Number", "INV-1", "unexpected invoice")
+	verifier.FieldEquals(verify, *decoded, "Lines", []string{"book", "pen"}, "unexpected invoice")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := []struct {
+		field    string
+		expected interface{}
+		message  string
+	}{
+		{"Paid", false, "unexpected invoice: field Paid is true, expected false"},
+		{"Number", 1, "unexpected invoice: field Number is INV-1, expected 1"},
+		{"Total", 10, "unexpected invoice: *verifier_test.invoice has no field Total"},
+		{"secret", "", "unexpected invoice: field secret of *verifier_test.invoice is unexported"},
+	}
+	for _, testCase := range testCases {
+		verify := verifier.New()
+		verifier.FieldEquals(verify, decoded, testCase.field, testCase.expected, "unexpected %s", "invoice")
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %s", testCase.field)
+		}
+		if verify.GetError().Error() != testCase.message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
+
+type auditInfo struct {
+	ID        int64
+	CreatedBy string
+}
+
+type auditedOrder struct {
+	*auditInfo
+	Item string
+}
+
+func TestFieldEquals_nil_embedded_pointer(t *testing.T) {
+	verify := verifier.New()
+	verifier.FieldEquals(verify, auditedOrder{Item: "book"}, "ID", int64(0), "unexpected order")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	expected := "unexpected order: field ID of verifier_test.auditedOrder can't be accessed: " +
+		"reflect: indirection through nil pointer to embedded struct field auditInfo"
+	if verify.GetError().Error() != expected {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+type Audit struct {
+	ID        int64
+	CreatedBy string
+	Score     float64
+}
+
+type auditedInvoice struct {
+	*Audit
+	Number string
+}
+
+func TestEqualExcept_embedded_pointer(t *testing.T) {
+	actual := auditedInvoice{Audit: &Audit{ID: 7, CreatedBy: "john"}, Number: "INV-1"}
+	expected := auditedInvoice{Audit: &Audit{ID: 9, CreatedBy: "john"}, Number: "INV-1"}
+	verify := verifier.New()
+	verifier.EqualExcept(verify, actual, &expected, []string{"ID"}, "unexpected invoice")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+	if actual.ID != 7 || expected.ID != 9 {
+		t.Errorf("compared structs should not be modified: %d, %d", actual.ID, expected.ID)
+	}
+
+	expected.CreatedBy = "jane"
+	verifier.EqualExcept(verify, actual, expected, []string{"ID"}, "unexpected invoice")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected invoice: field Audit differs: &{0 john 0} != &{0 jane 0}" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+	if actual.ID != 7 || expected.ID != 9 {
+		t.Errorf("compared structs should not be modified: %d, %d", actual.ID, expected.ID)
+	}
+
+	verify = verifier.New()
+	verifier.EqualExcept(verify, auditedInvoice{Number: "INV-1"}, auditedInvoice{Number: "INV-1"}, []string{"ID"}, "unexpected invoice")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+}
+
+func TestEqualWithTolerance_embedded_pointer(t *testing.T) {
+	actual := auditedInvoice{Audit: &Audit{ID: 7, Score: 0.91}, Number: "INV-1"}
+	expected := auditedInvoice{Audit: &Audit{ID: 7, Score: 0.9}, Number: "INV-1"}
+	tolerances := map[string]float64{"Score": 0.05}
+	verify := verifier.New()
+	verifier.EqualWithTolerance(verify, &actual, expected, tolerances, "unexpected invoice")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+	if actual.Score != 0.91 || expected.Score != 0.9 {
+		t.Errorf("compared structs should not be modified: %v, %v", actual.Score, expected.Score)
+	}
+
+	actual.Score = 0.99
+	verifier.EqualWithTolerance(verify, actual, expected, tolerances, "unexpected invoice")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected invoice: field Score differs: 0.99 != 0.9, tolerance is 0.05" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.EqualWithTolerance(verify, auditedInvoice{Number: "INV-1"}, expected, tolerances, "unexpected invoice")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unexpected invoice: field Audit differs: <nil> != &{7  0}" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
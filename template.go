@@ -0,0 +1,32 @@
+package verifier
+
+import (
+	"strings"
+	"text/template"
+)
+
+// ThatTemplate verifies condition passed as first argument, same as That,
+// but on failure message is produced by executing template with data.
+// If template execution fails, its error becomes the failure message.
+// Template is executed only if condition is false, so it can be nil if condition is known to hold.
+// Nil template of failed check is reported as failure.
+func (v *Verify) ThatTemplate(positiveCondition bool, tmpl *template.Template, data interface{}) *Verify {
+	name := "nil template"
+	if tmpl != nil {
+		name = tmpl.Name()
+	}
+	return v.check("%s", []interface{}{name}, func(v *Verify) error {
+		if positiveCondition {
+			return nil
+		}
+		if tmpl == nil {
+			return v.errorf("failure message template is nil")
+		}
+		message := &strings.Builder{}
+		err := tmpl.Execute(message, data)
+		if err != nil {
+			return v.errorf("can't execute failure message template: %v", err)
+		}
+		return v.errorf("%s", message)
+	})
+}
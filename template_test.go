@@ -0,0 +1,58 @@
+package verifier_test
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/storozhukBM/verifier"
+)
+
+var ageTemplate = template.Must(template.New("age").Parse("age should be {{.MinAge}} or higher, but yours: {{.Age}}"))
+
+func TestVerifier_ThatTemplate(t *testing.T) {
+	type ageData struct {
+		MinAge int
+		Age    int
+	}
+	verify := verifier.New()
+	verify.ThatTemplate(true, ageTemplate, nil)
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verify.ThatTemplate(false, ageTemplate, ageData{MinAge: 21, Age: 18})
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "age should be 21 or higher, but yours: 18" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestVerifier_ThatTemplate_execution_error(t *testing.T) {
+	verify := verifier.New()
+	verify.ThatTemplate(false, ageTemplate, struct{ Age int }{Age: 18})
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if !strings.HasPrefix(verify.GetError().Error(), "can't execute failure message template: template: age:1:") {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestVerifier_ThatTemplate_nil_template(t *testing.T) {
+	verify := verifier.New()
+	verify.ThatTemplate(true, nil, nil)
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verify.ThatTemplate(false, nil, struct{ Age int }{Age: 18})
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "failure message template is nil" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
@@ -0,0 +1,156 @@
+package verifier
+
+import "time"
+
+// Clock is a source of time for time dependent checks (default: system clock).
+// Use Verify.WithClock to control time in tests.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// WithClock sets source of time for time dependent checks of this verifier (default: system clock).
+func (v *Verify) WithClock(clock Clock) *Verify {
+	v.clock = clock
+	return v
+}
+
+// WithDeadline sets deadline for the whole verification chain.
+// Before each subsequent check, verifier compares deadline with current time of its clock
+// and if deadline has passed, it fails with timeout error instead of evaluating the check.
+func (v *Verify) WithDeadline(d time.Time) *Verify {
+	v.deadline = d
+	return v
+}
+
+func (v *Verify) now() time.Time {
+	if v.clock == nil {
+		return systemClock{}.Now()
+	}
+	return v.clock.Now()
+}
+
+func (v *Verify) sleep(d time.Duration) {
+	if v.clock == nil {
+		systemClock{}.Sleep(d)
+		return
+	}
+	v.clock.Sleep(d)
+}
+
+func (v *Verify) deadlineExceeded() bool {
+	return !v.deadline.IsZero() && v.now().After(v.deadline)
+}
+
+// PositiveDuration verifies that duration is greater than zero.
+// On failure, message is followed by actual duration.
+func PositiveDuration(v *Verify, d time.Duration, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if d > 0 {
+			return nil
+		}
+		return v.errorfWithDetail(message, args, "duration is %v", d)
+	})
+}
+
+// NonNegativeDuration verifies that duration is zero or greater.
+// On failure, message is followed by actual duration.
+func NonNegativeDuration(v *Verify, d time.Duration, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if d >= 0 {
+			return nil
+		}
+		return v.errorfWithDetail(message, args, "duration is %v", d)
+	})
+}
+
+// NearNow verifies that t differs from current time of verifier's clock by no more than skew.
+// On failure, message is followed by actual difference.
+func NearNow(v *Verify, t time.Time, skew time.Duration, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		diff := v.now().Sub(t)
+		if -skew <= diff && diff <= skew {
+			return nil
+		}
+		return v.errorfWithDetail(message, args, "%v differs from now by %v", t.Format(time.RFC3339Nano), diff)
+	})
+}
+
+// Eventually polls pred every interval until it returns true or timeout elapses,
+// e.g. to wait for eventually consistent state in integration tests.
+// Polling uses clock of the verifier, see WithClock.
+// On failure, message is followed by the timeout.
+func (v *Verify) Eventually(pred func() bool, timeout, interval time.Duration, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		deadline := v.now().Add(timeout)
+		for !pred() {
+			if !v.now().Before(deadline) {
+				return v.errorfWithDetail(message, args, "condition not met within %v", timeout)
+			}
+			v.sleep(interval)
+		}
+		return nil
+	})
+}
+
+// Never polls pred every interval for duration and verifies that it never returns true,
+// e.g. to make sure that no error events fire.
+// Polling uses clock of the verifier, see WithClock.
+// On failure, message is followed by time elapsed before pred returned true.
+func (v *Verify) Never(pred func() bool, duration, interval time.Duration, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		start := v.now()
+		deadline := start.Add(duration)
+		for {
+			if pred() {
+				return v.errorfWithDetail(message, args, "condition met after %v", v.now().Sub(start))
+			}
+			if !v.now().Before(deadline) {
+				return nil
+			}
+			v.sleep(interval)
+		}
+	})
+}
+
+// NoTimeOverlap verifies that time intervals [startA, endA) and [startB, endB) don't overlap, e.g. booking slots.
+// Intervals touching only by boundary, like endA == startB, are not overlapping.
+// On failure, message is followed by both intervals.
+func NoTimeOverlap(v *Verify, startA, endA, startB, endB time.Time, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if !(startA.Before(endB) && startB.Before(endA)) {
+			return nil
+		}
+		return v.errorfWithDetail(
+			message, args, "[%s, %s) overlaps [%s, %s)",
+			startA.Format(time.RFC3339Nano), endA.Format(time.RFC3339Nano),
+			startB.Format(time.RFC3339Nano), endB.Format(time.RFC3339Nano),
+		)
+	})
+}
+
+// TimeAligned verifies that t lands on boundary of period, i.e. `t.Truncate(period).Equal(t)`,
+// e.g. that timestamp of metrics bucket is on minute boundary.
+// Non-positive period is reported as failure.
+// On failure, message is followed by the time and the period.
+func TimeAligned(v *Verify, t time.Time, period time.Duration, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if period <= 0 {
+			return v.errorfWithDetail(message, args, "period %v is not positive", period)
+		}
+		if !t.Truncate(period).Equal(t) {
+			return v.errorfWithDetail(message, args, "%s is not aligned to %v", t.Format(time.RFC3339Nano), period)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,235 @@
+package verifier_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/storozhukBM/verifier"
+)
+
+func TestPositiveDuration(t *testing.T) {
+	verify := verifier.New()
+	verifier.PositiveDuration(verify, time.Second, "timeout should be positive")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.PositiveDuration(verify, 0, "timeout should be positive")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "timeout should be positive: duration is 0s" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.PositiveDuration(verify, -time.Minute, "%s should be positive", "interval")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "interval should be positive: duration is -1m0s" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestNonNegativeDuration(t *testing.T) {
+	verify := verifier.New()
+	verifier.NonNegativeDuration(verify, time.Second, "delay can't be negative")
+	verifier.NonNegativeDuration(verify, 0, "delay can't be negative")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.NonNegativeDuration(verify, -time.Millisecond, "delay can't be negative")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "delay can't be negative: duration is -1ms" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+// fakeClock is a manually controlled clock, Sleep just advances its time.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestVerifier_WithDeadline(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)}
+	expensive := func() bool {
+		clock.Sleep(time.Second)
+		return true
+	}
+
+	verify := verifier.New().WithClock(clock).WithDeadline(clock.Now().Add(3 * time.Second))
+	verify.Predicate(expensive, "first check")
+	verify.Predicate(expensive, "second check")
+	verify.Predicate(expensive, "third check")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	calls := 0
+	verify.Predicate(expensive, "fourth check")
+	verify.Predicate(func() bool {
+		calls++
+		return true
+	}, "fifth check")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "verification deadline 2018-06-01T12:00:03Z exceeded" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+	if calls != 0 {
+		t.Errorf("unexpected evaluations happened")
+	}
+}
+
+func TestNearNow(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)}
+	verify := verifier.New().WithClock(clock)
+	verifier.NearNow(verify, clock.Now().Add(-4*time.Second), 5*time.Second, "stale timestamp")
+	verifier.NearNow(verify, clock.Now().Add(5*time.Second), 5*time.Second, "stale timestamp")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.NearNow(verify, clock.Now().Add(-time.Minute), 5*time.Second, "stale %s", "timestamp")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "stale timestamp: 2018-06-01T11:59:00Z differs from now by 1m0s" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.NearNow(verify, time.Now(), time.Minute, "stale timestamp")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should use system clock by default: %s", verify.GetError())
+	}
+}
+
+func TestVerify_Eventually(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)}
+	start := clock.Now()
+	replicated := func() bool {
+		return clock.Now().Sub(start) >= 3*time.Second
+	}
+
+	verify := verifier.New().WithClock(clock)
+	verify.Eventually(replicated, 5*time.Second, time.Second, "replica should catch up")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+	if clock.Now().Sub(start) != 3*time.Second {
+		t.Errorf("unexpected polling duration: %v", clock.Now().Sub(start))
+	}
+
+	polls := 0
+	verify.Eventually(func() bool {
+		polls++
+		return false
+	}, 5*time.Second, time.Second, "%s should catch up", "replica")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "replica should catch up: condition not met within 5s" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+	if polls != 6 {
+		t.Errorf("unexpected number of polls: %d", polls)
+	}
+}
+
+func TestVerify_Never(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)}
+	start := clock.Now()
+	polls := 0
+	verify := verifier.New().WithClock(clock)
+	verify.Never(func() bool {
+		polls++
+		return false
+	}, 5*time.Second, time.Second, "no error events expected")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+	if polls != 6 {
+		t.Errorf("unexpected number of polls: %d", polls)
+	}
+
+	start = clock.Now()
+	errorFired := func() bool {
+		return clock.Now().Sub(start) >= 2*time.Second
+	}
+	verify.Never(errorFired, 5*time.Second, time.Second, "no %s events expected", "error")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "no error events expected: condition met after 2s" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestNoTimeOverlap(t *testing.T) {
+	nine := time.Date(2018, 6, 1, 9, 0, 0, 0, time.UTC)
+	ten := nine.Add(time.Hour)
+	eleven := ten.Add(time.Hour)
+	noon := eleven.Add(time.Hour)
+
+	verify := verifier.New()
+	verifier.NoTimeOverlap(verify, nine, ten, ten, eleven, "slots should not overlap")
+	verifier.NoTimeOverlap(verify, ten, eleven, nine, ten, "slots should not overlap")
+	verifier.NoTimeOverlap(verify, nine, ten, eleven, noon, "slots should not overlap")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.NoTimeOverlap(verify, nine, eleven, ten, noon, "%s should not overlap", "slots")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	expected := "slots should not overlap: " +
+		"[2018-06-01T09:00:00Z, 2018-06-01T11:00:00Z) overlaps [2018-06-01T10:00:00Z, 2018-06-01T12:00:00Z)"
+	if verify.GetError().Error() != expected {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestTimeAligned(t *testing.T) {
+	bucket := time.Date(2018, 6, 1, 12, 30, 0, 0, time.UTC)
+	verify := verifier.New()
+	verifier.TimeAligned(verify, bucket, time.Minute, "bucket should be aligned")
+	verifier.TimeAligned(verify, bucket, 30*time.Minute, "bucket should be aligned")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := []struct {
+		time    time.Time
+		period  time.Duration
+		message string
+	}{
+		{bucket, time.Hour, "bucket should be aligned: 2018-06-01T12:30:00Z is not aligned to 1h0m0s"},
+		{bucket.Add(time.Millisecond), time.Minute, "bucket should be aligned: 2018-06-01T12:30:00.001Z is not aligned to 1m0s"},
+		{bucket, 0, "bucket should be aligned: period 0s is not positive"},
+	}
+	for _, testCase := range testCases {
+		verify := verifier.New()
+		verifier.TimeAligned(verify, testCase.time, testCase.period, "%s should be aligned", "bucket")
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %s", testCase.message)
+		}
+		if verify.GetError().Error() != testCase.message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package verifier
+
+import "reflect"
+
+// IsOneOfTypes verifies that dynamic type of obj is one of types.
+// Nil obj has no dynamic type, so it always fails.
+// On failure, message is followed by actual type.
+func IsOneOfTypes(v *Verify, obj interface{}, types []reflect.Type, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if obj == nil {
+			return v.errorfWithDetail(message, args, "value is nil")
+		}
+		actual := reflect.TypeOf(obj)
+		for _, t := range types {
+			if actual == t {
+				return nil
+			}
+		}
+		return v.errorfWithDetail(message, args, "unexpected type %v", actual)
+	})
+}
+
+// IsError verifies that obj is a non-nil value implementing error interface.
+// Typed nil pointer implementing error is considered nil as well.
+// On failure, message is followed by actual type or by notice that value is nil.
+func (v *Verify) IsError(obj interface{}, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		value := reflect.ValueOf(obj)
+		if obj == nil || (value.Kind() == reflect.Ptr && value.IsNil()) {
+			return v.errorfWithDetail(message, args, "value is nil")
+		}
+		if _, ok := obj.(error); !ok {
+			return v.errorfWithDetail(message, args, "%T is not an error", obj)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,65 @@
+package verifier_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+)
+
+func TestIsOneOfTypes(t *testing.T) {
+	jsonScalars := []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(0.0), reflect.TypeOf(false)}
+	verify := verifier.New()
+	verifier.IsOneOfTypes(verify, "John", jsonScalars, "scalar expected")
+	verifier.IsOneOfTypes(verify, 42.0, jsonScalars, "scalar expected")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	verifier.IsOneOfTypes(verify, []interface{}{"John"}, jsonScalars, "%s expected", "scalar")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "scalar expected: unexpected type []interface {}" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	verifier.IsOneOfTypes(verify, nil, jsonScalars, "scalar expected")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "scalar expected: value is nil" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestVerifier_IsError(t *testing.T) {
+	verify := verifier.New()
+	verify.IsError(errors.New("connection refused"), "error expected")
+	verify.IsError(&os.PathError{Op: "open", Path: "/etc/app.conf", Err: os.ErrNotExist}, "error expected")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := []struct {
+		obj     interface{}
+		message string
+	}{
+		{"connection refused", "error expected: string is not an error"},
+		{nil, "error expected: value is nil"},
+		{(*os.PathError)(nil), "error expected: value is nil"},
+	}
+	for _, testCase := range testCases {
+		verify := verifier.New()
+		verify.IsError(testCase.obj, "%s expected", "error")
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %#v", testCase.obj)
+		}
+		if verify.GetError().Error() != testCase.message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
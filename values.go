@@ -0,0 +1,84 @@
+package verifier
+
+// Map applies fn to value and verifies that it returns no error.
+// It returns result of fn and verifier for further checks.
+// On failure, message is followed by error returned from fn and zero value is returned.
+// After the first failed verification fn won't be called and zero value is returned.
+func Map[T, R any](v *Verify, value T, fn func(T) (R, error), message string, args ...interface{}) (R, *Verify) {
+	var result R
+	v = v.check(message, args, func(v *Verify) error {
+		mapped, err := fn(value)
+		if err != nil {
+			return v.errorfWithDetail(message, args, "%v", err)
+		}
+		result = mapped
+		return nil
+	})
+	return result, v
+}
+
+// Coerce parses raw string using parse function and stores the result into dest.
+// On failure, message is followed by parse error and dest is left untouched.
+// After the first failed verification raw won't be parsed.
+func Coerce[T any](v *Verify, raw string, parse func(string) (T, error), dest *T, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if dest == nil {
+			return v.errorfWithDetail(message, args, "destination is nil")
+		}
+		parsed, err := parse(raw)
+		if err != nil {
+			return v.errorfWithDetail(message, args, "%v", err)
+		}
+		*dest = parsed
+		return nil
+	})
+}
+
+// Deref verifies that pointer p is not nil and returns value it points to.
+// On failure, zero value is returned.
+// After the first failed verification p won't be dereferenced and zero value is returned.
+func Deref[T any](v *Verify, p *T, message string, args ...interface{}) (T, *Verify) {
+	var result T
+	v = v.check(message, args, func(v *Verify) error {
+		if p == nil {
+			return v.errorf(message, args...)
+		}
+		result = *p
+		return nil
+	})
+	return result, v
+}
+
+// Lookup verifies that map m contains key and returns value stored under it.
+// On failure, message is followed by the missing key and zero value is returned.
+// Nil map is treated as empty.
+// After the first failed verification m won't be accessed and zero value is returned.
+func Lookup[K comparable, V any](v *Verify, m map[K]V, key K, message string, args ...interface{}) (V, *Verify) {
+	var result V
+	v = v.check(message, args, func(v *Verify) error {
+		value, ok := m[key]
+		if !ok {
+			return v.errorfWithDetail(message, args, "key %v is absent", key)
+		}
+		result = value
+		return nil
+	})
+	return result, v
+}
+
+// First returns the first element of slice s matching pred, verifying that there is one.
+// On failure, zero value is returned.
+// After the first failed verification s won't be searched and zero value is returned.
+func First[T any](v *Verify, s []T, pred func(T) bool, message string, args ...interface{}) (T, *Verify) {
+	var result T
+	v = v.check(message, args, func(v *Verify) error {
+		for _, item := range s {
+			if pred(item) {
+				result = item
+				return nil
+			}
+		}
+		return v.errorf(message, args...)
+	})
+	return result, v
+}
@@ -0,0 +1,189 @@
+package verifier_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+)
+
+func TestMap(t *testing.T) {
+	verify := verifier.New()
+	port, verify := verifier.Map(verify, "8080", strconv.Atoi, "invalid port")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+	if port != 8080 {
+		t.Errorf("unexpected result: %d", port)
+	}
+
+	port, verify = verifier.Map(verify, "80a", strconv.Atoi, "invalid %s", "port")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != `invalid port: strconv.Atoi: parsing "80a": invalid syntax` {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+	if port != 0 {
+		t.Errorf("unexpected result: %d", port)
+	}
+}
+
+func TestMap_not_evaluate_after_failure(t *testing.T) {
+	calls := 0
+	verify := verifier.New()
+	verify.That(false, "first failure")
+	port, verify := verifier.Map(verify, "8080", func(s string) (int, error) {
+		calls++
+		return strconv.Atoi(s)
+	}, "invalid port")
+	if verify.GetError().Error() != "first failure" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+	if port != 0 {
+		t.Errorf("unexpected result: %d", port)
+	}
+	if calls != 0 {
+		t.Errorf("unexpected evaluations happened")
+	}
+}
+
+func TestCoerce(t *testing.T) {
+	var config struct {
+		Port    int
+		Workers int
+	}
+	verify := verifier.New()
+	verifier.Coerce(verify, "8080", strconv.Atoi, &config.Port, "invalid port")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+	if config.Port != 8080 {
+		t.Errorf("unexpected result: %d", config.Port)
+	}
+
+	config.Workers = 4
+	verifier.Coerce(verify, "four", strconv.Atoi, &config.Workers, "invalid %s", "workers")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != `invalid workers: strconv.Atoi: parsing "four": invalid syntax` {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+	if config.Workers != 4 {
+		t.Errorf("destination should not be changed on failure: %d", config.Workers)
+	}
+}
+
+func TestCoerce_not_evaluate_after_failure(t *testing.T) {
+	calls := 0
+	port := 0
+	verify := verifier.New()
+	verify.That(false, "first failure")
+	verifier.Coerce(verify, "8080", func(s string) (int, error) {
+		calls++
+		return strconv.Atoi(s)
+	}, &port, "invalid port")
+	if verify.GetError().Error() != "first failure" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+	if calls != 0 || port != 0 {
+		t.Errorf("unexpected evaluations happened")
+	}
+}
+
+func TestDeref(t *testing.T) {
+	type person struct {
+		Name string
+	}
+	verify := verifier.New()
+	p, verify := verifier.Deref(verify, &person{Name: "John"}, "person can't be nil")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+	if p.Name != "John" {
+		t.Errorf("unexpected result: %+v", p)
+	}
+
+	p, verify = verifier.Deref(verify, (*person)(nil), "%s can't be nil", "person")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "person can't be nil" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+	if p != (person{}) {
+		t.Errorf("unexpected result: %+v", p)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	ports := map[string]int{"http": 80, "https": 443}
+	verify := verifier.New()
+	port, verify := verifier.Lookup(verify, ports, "https", "unknown protocol")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+	if port != 443 {
+		t.Errorf("unexpected result: %d", port)
+	}
+
+	port, verify = verifier.Lookup(verify, ports, "ftp", "unknown %s", "protocol")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unknown protocol: key ftp is absent" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+	if port != 0 {
+		t.Errorf("unexpected result: %d", port)
+	}
+
+	verify = verifier.New()
+	port, verify = verifier.Lookup(verify, map[string]int(nil), "http", "unknown protocol")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "unknown protocol: key http is absent" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+	if port != 0 {
+		t.Errorf("unexpected result: %d", port)
+	}
+}
+
+func TestFirst(t *testing.T) {
+	type account struct {
+		ID      string
+		Primary bool
+	}
+	isPrimary := func(a account) bool { return a.Primary }
+	accounts := []account{{ID: "savings"}, {ID: "checking", Primary: true}, {ID: "credit", Primary: true}}
+
+	verify := verifier.New()
+	primary, verify := verifier.First(verify, accounts, isPrimary, "primary account should exist")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+	if primary.ID != "checking" {
+		t.Errorf("unexpected result: %+v", primary)
+	}
+
+	testCases := map[string][]account{
+		"no match": accounts[:1],
+		"empty":    nil,
+	}
+	for name, accounts := range testCases {
+		verify := verifier.New()
+		primary, verify := verifier.First(verify, accounts, isPrimary, "%s account should exist", "primary")
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %s", name)
+		}
+		if verify.GetError().Error() != "primary account should exist" {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+		if primary != (account{}) {
+			t.Errorf("unexpected result: %+v", primary)
+		}
+	}
+}
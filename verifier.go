@@ -1,12 +1,20 @@
 package verifier
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"reflect"
 	"runtime"
+	"sort"
+	"strconv"
+	"strings"
 	"sync/atomic"
+	"time"
 )
 
 // New creates verification instance (recommended).
@@ -18,13 +26,13 @@ import (
 func New() *Verify {
 	v := &Verify{
 		creationStack: captureCreationStack(),
-		errFactory:    fmt.Errorf,
 	}
 	runtime.SetFinalizer(v, printWarningOnUncheckedVerification)
 	return v
 }
 
-// WithErrFactory sets error construction function (default: fmt.Errorf).
+// WithErrFactory sets error construction function for this verifier,
+// overriding the one set by SetDefaultErrFactory (default: fmt.Errorf wrapping ErrVerificationFailed).
 // Use it to set custom error type of error, returned by Verify.GetError().
 func (v *Verify) WithErrFactory(factory func(string, ...interface{}) error) *Verify {
 	v.errFactory = factory
@@ -51,75 +59,150 @@ func Offensive() *Verify {
 // After one failed check all others won't count and predicates won't be evaluated.
 // Use Verify.GetError function to check if there where any during verification process.
 type Verify struct {
-	creationStack []uintptr
-	err           error
-	errFactory    func(string, ...interface{}) error
-	checked       bool
+	creationStack  []uintptr
+	err            error
+	errFactory     func(string, ...interface{}) error
+	checked        bool
+	recording      bool
+	records        []CheckResult
+	details        map[string]interface{}
+	fields         map[string]interface{}
+	clock          Clock
+	deadline       time.Time
+	requestID      string
+	findings       map[Severity][]string
+	onceResults    map[string]CheckResult
+	panicOnFailure bool
+	writer         io.Writer
 }
 
 // WithError verifies condition passed as first argument.
 // If `positiveCondition == true`, verification will proceed for other checks.
 // If `positiveCondition == false`, internal state will be filled with error specified as second argument.
+// Passed check is recorded with message of err, or as "check without error" if err is nil.
 // After the first failed verification all others won't count and predicates won't be evaluated.
 func (v *Verify) WithError(positiveCondition bool, err error) *Verify {
-	vObj := v
-	if v == nil {
-		vObj = &Verify{}
-	}
-
-	vObj.checked = false
-	if vObj.err != nil {
-		return vObj
-	}
-	if positiveCondition {
-		return vObj
+	message, args := "%v", []interface{}{err}
+	if err == nil {
+		message, args = "check without error", nil
 	}
-	vObj.err = err
-	return vObj
+	return v.check(message, args, func(v *Verify) error {
+		if positiveCondition {
+			return nil
+		}
+		return err
+	})
 }
 
 // That verifies condition passed as first argument.
 // If `positiveCondition == true`, verification will proceed for other checks.
 // If `positiveCondition == false`, internal state will be filled with error,
-// using message argument as format in error factory func(message, args...) (default: fmt.Errorf).
+// using message argument as format in error factory func(message, args...) (default: fmt.Errorf wrapping ErrVerificationFailed).
 // After the first failed verification all others won't count and predicates won't be evaluated.
 func (v *Verify) That(positiveCondition bool, message string, args ...interface{}) *Verify {
-	vObj := v
-	if v == nil {
-		vObj = &Verify{}
-	}
-	vObj.checked = false
-	if vObj.err != nil {
-		return vObj
-	}
-	if positiveCondition {
-		return vObj
-	}
-	vObj.err = vObj.errorf(message, args...)
-	return vObj
+	return v.check(message, args, func(v *Verify) error {
+		if positiveCondition {
+			return nil
+		}
+		return v.errorf(message, args...)
+	})
 }
 
 // That evaluates predicate passed as first argument.
 // If `predicate() == true`, verification will proceed for other checks.
 // If `predicate() == false`, internal state will be filled with error,
-// using message argument as format in error factory func(message, args...) (default: fmt.Errorf).
+// using message argument as format in error factory func(message, args...) (default: fmt.Errorf wrapping ErrVerificationFailed).
 // After the first failed verification all others won't count and predicates won't be evaluated.
 func (v *Verify) Predicate(predicate func() bool, message string, args ...interface{}) *Verify {
+	return v.check(message, args, func(v *Verify) error {
+		if predicate() {
+			return nil
+		}
+		return v.errorf(message, args...)
+	})
+}
+
+// WithDetail attaches structured detail to the next check only.
+// If that check fails, its error is wrapped into DetailedError carrying all attached details.
+// Details are discarded after the next check, whether it fails or not.
+func (v *Verify) WithDetail(key string, value interface{}) *Verify {
 	vObj := v
 	if v == nil {
 		vObj = &Verify{}
 	}
-	vObj.checked = false
-	if vObj.err != nil {
-		return vObj
+	if vObj.details == nil {
+		vObj.details = make(map[string]interface{})
 	}
-	if predicate() {
-		return vObj
+	vObj.details[key] = value
+	return vObj
+}
+
+// WithWriter sets writer for unhandled verification warning of this verifier,
+// overriding UnhandledVerificationsWriter set by SetUnhandledVerificationsWriter.
+// Use it to isolate output, e.g. in parallel tests. Pass nil to fall back to the global writer.
+func (v *Verify) WithWriter(w io.Writer) *Verify {
+	v.writer = w
+	return v
+}
+
+// WithContextFields reads values of keys from ctx and attaches them to verifier as fields,
+// e.g. to propagate request-scoped trace or user IDs into verification failures.
+// Fields are added to details of DetailedError of any subsequent failed check and to LogValue.
+// Field name is key formatted using fmt.Sprint. Keys missing in ctx are skipped.
+func (v *Verify) WithContextFields(ctx context.Context, keys ...interface{}) *Verify {
+	vObj := v
+	if v == nil {
+		vObj = &Verify{}
+	}
+	for _, key := range keys {
+		value := ctx.Value(key)
+		if value == nil {
+			continue
+		}
+		if vObj.fields == nil {
+			vObj.fields = make(map[string]interface{})
+		}
+		vObj.fields[fmt.Sprint(key)] = value
 	}
-	vObj.err = vObj.errorf(message, args...)
 	return vObj
 }
 
+// FieldKeys returns keys of fields attached using WithContextFields, sorted alphabetically for stable output.
+// Verifier without fields returns empty slice.
+func (v *Verify) FieldKeys() []string {
+	if v == nil {
+		return []string{}
+	}
+	return sortedFieldNames(v.fields)
+}
+
+func sortedFieldNames(fields map[string]interface{}) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithRequestID sets correlation ID of the request this verifier belongs to.
+// ID is included into unhandled verification warning, so it can be traced in logs.
+func (v *Verify) WithRequestID(id string) *Verify {
+	v.requestID = id
+	return v
+}
+
+// MapError replaces verification error with the result of fn applied to it.
+// Use it to uniformly convert verification failures at the end of a chain.
+// If there were no failures, fn won't be called.
+func (v *Verify) MapError(fn func(error) error) *Verify {
+	if v == nil || v.err == nil {
+		return v
+	}
+	v.err = fn(v.err)
+	return v
+}
+
 // GetError extracts error from internal state to check if there where any during verification process.
 func (v *Verify) GetError() error {
 	if v == nil {
@@ -129,7 +212,30 @@ func (v *Verify) GetError() error {
 	return v.err
 }
 
-// PanicOnError panics if there is an error in internal state.
+// Scope returns function that marks verifier as checked, intended to be used as `defer verify.Scope()()`.
+// It suppresses unhandled verification warning for verifier, which result is deliberately not inspected,
+// once enclosing scope is exited. Verifier stays usable until then.
+func (v *Verify) Scope() func() {
+	return func() {
+		if v != nil {
+			v.checked = true
+		}
+	}
+}
+
+// ErrorWithStack extracts error from internal state, same as GetError,
+// together with the stack where verifier was created.
+// Stack is empty for verifiers that don't track verification state, like zero verifier `Verify{}`.
+func (v *Verify) ErrorWithStack() (error, []uintptr) {
+	if v == nil {
+		return errors.New("verifier instance is nil"), nil
+	}
+	stack := make([]uintptr, len(v.creationStack))
+	copy(stack, v.creationStack)
+	return v.GetError(), stack
+}
+
+// PanicOnError panics with PanicError if there is an error in internal state.
 // Created for people who adopt offensive programming(https://en.wikipedia.org/wiki/Offensive_programming).
 func (v *Verify) PanicOnError() {
 	if v == nil {
@@ -137,7 +243,7 @@ func (v *Verify) PanicOnError() {
 	}
 	v.checked = true
 	if v.err != nil {
-		panic("verification failure: " + v.err.Error())
+		panic(PanicError{Err: v.err})
 	}
 }
 
@@ -152,30 +258,196 @@ func (v *Verify) String() string {
 	return "verification failure: " + v.err.Error()
 }
 
+// IsValid reports whether verifier is in self-consistent tracked state,
+// i.e. it was created by New, Offensive or NewRecording and its internal state wasn't corrupted.
+// Zero value verifier and nil are not valid, though they still can be used for verification.
+// It's intended for libraries that embed or accept verifiers and want to guard their invariants.
+func (v *Verify) IsValid() bool {
+	if v == nil || len(v.creationStack) == 0 {
+		return false
+	}
+	if !v.recording && len(v.records) > 0 {
+		return false
+	}
+	return true
+}
+
+// check is the common path of all verifications.
+// After the first failure it does nothing, otherwise it evaluates the check
+// and stores returned error as verification failure.
+// Message and args describe the check if it passes and verifier records all checks.
+func (v *Verify) check(message string, args []interface{}, evaluate func(v *Verify) error) *Verify {
+	vObj := v
+	if v == nil {
+		vObj = &Verify{}
+	}
+	vObj.checked = false
+	details := vObj.details
+	vObj.details = nil
+	if vObj.err != nil {
+		return vObj
+	}
+	if vObj.deadlineExceeded() {
+		vObj.err = vObj.errorf("verification deadline %s exceeded", vObj.deadline.Format(time.RFC3339Nano))
+	} else {
+		atomic.AddInt64(&totalChecksEvaluated, 1)
+		vObj.err = evaluate(vObj)
+	}
+	if vObj.err != nil && atomic.LoadInt32(&includeCallerInMessage) == 1 {
+		vObj.err = &locatedError{err: vObj.err, location: callerLocation()}
+	}
+	if vObj.err != nil && (details != nil || vObj.fields != nil) {
+		merged := make(map[string]interface{}, len(vObj.fields)+len(details))
+		for key, value := range vObj.fields {
+			merged[key] = value
+		}
+		for key, value := range details {
+			merged[key] = value
+		}
+		vObj.err = &DetailedError{Err: vObj.err, Details: merged}
+	}
+	if vObj.recording {
+		vObj.record(message, args)
+	}
+	if vObj.err != nil && vObj.panicOnFailure {
+		vObj.checked = true
+		panic(PanicError{Err: vObj.err})
+	}
+	return vObj
+}
+
+var totalChecksEvaluated int64
+
+// TotalChecksEvaluated returns number of checks evaluated by all verifiers since the process start.
+// Checks skipped after the first failure are not counted.
+// Use it to correlate cost of verification with throughput, e.g. in load tests.
+func TotalChecksEvaluated() int64 {
+	return atomic.LoadInt64(&totalChecksEvaluated)
+}
+
+var includeCallerInMessage int32
+
+// SetIncludeCallerInMessage enables or disables suffixing failure messages
+// with file:line of the check call site (default: disabled).
+// Call site is captured only for failed checks when this option is enabled.
+func SetIncludeCallerInMessage(enabled bool) {
+	value := int32(0)
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&includeCallerInMessage, value)
+}
+
+var packagePath = reflect.TypeOf(Verify{}).PkgPath()
+
+// callerLocation returns file:line of the first caller outside of verifier package and its subpackages.
+func callerLocation() string {
+	var rawStack [32]uintptr
+	numberOfFrames := runtime.Callers(2, rawStack[:])
+	frames := runtime.CallersFrames(rawStack[:numberOfFrames])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePath+".") && !strings.HasPrefix(frame.Function, packagePath+"/") {
+			return filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// errorfWithDetail builds error from user message followed by detail describing the failure.
+func (v *Verify) errorfWithDetail(message string, args []interface{}, detail string, detailArgs ...interface{}) error {
+	return v.errorf(message+": "+detail, append(args[:len(args):len(args)], detailArgs...)...)
+}
+
 func (v *Verify) errorf(message string, args ...interface{}) error {
-	if v.errFactory == nil {
-		v.errFactory = fmt.Errorf
+	if v.errFactory != nil {
+		return v.errFactory(message, args...)
+	}
+	factory := newVerificationError
+	rawFactory := defaultErrFactory.Load()
+	if rawFactory != nil && rawFactory.(errFactoryWrapper).value != nil {
+		factory = rawFactory.(errFactoryWrapper).value
 	}
-	return v.errFactory(message, args...)
+	return factory(message, args...)
 }
 
 func (v *Verify) printCreationStack(writer io.Writer) {
+	keep := func(runtime.Frame) bool { return true }
+	rawFilter := stackFilter.Load()
+	if rawFilter != nil && rawFilter.(stackFilterWrapper).value != nil {
+		keep = rawFilter.(stackFilterWrapper).value
+	}
 	frames := runtime.CallersFrames(v.creationStack)
 	for {
 		frame, more := frames.Next()
-		fmt.Fprintf(writer, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if keep(frame) {
+			fmt.Fprintf(writer, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		}
 		if !more {
 			break
 		}
 	}
 }
 
+type stackFilterWrapper struct {
+	value func(frame runtime.Frame) bool
+}
+
+var stackFilter atomic.Value
+
+// SetStackFilter gives you ability to drop frames, e.g. standard library or vendored ones,
+// from creation stack printed in unhandled verification warnings (default: all frames are printed).
+// Frame is printed only if filter returns true for it. Pass nil to restore default.
+func SetStackFilter(filter func(frame runtime.Frame) bool) {
+	stackFilter.Store(stackFilterWrapper{filter})
+}
+
 func failProcessOnUncheckedVerification(v *Verify) {
 	if v.checked {
 		return
 	}
 	printWarningOnUncheckedVerification(v)
-	os.Exit(1)
+	FlushWarnings()
+	rawHook := preExitHook.Load()
+	if rawHook != nil && rawHook.(preExitHookWrapper).value != nil {
+		rawHook.(preExitHookWrapper).value()
+	}
+	exit := os.Exit
+	rawExit := offensiveExitFunc.Load()
+	if rawExit != nil && rawExit.(exitFuncWrapper).value != nil {
+		exit = rawExit.(exitFuncWrapper).value
+	}
+	exit(1)
+}
+
+type exitFuncWrapper struct {
+	value func(code int)
+}
+
+var offensiveExitFunc atomic.Value
+
+// SetOffensiveExitFunc gives you ability to override function (default: os.Exit),
+// that stops the process when unchecked verification created by Offensive is found.
+// Use it to intercept exit, e.g. to flush logs first or in tests.
+// Pass nil to restore default.
+func SetOffensiveExitFunc(exit func(code int)) {
+	offensiveExitFunc.Store(exitFuncWrapper{exit})
+}
+
+type preExitHookWrapper struct {
+	value func()
+}
+
+var preExitHook atomic.Value
+
+// SetPreExitHook sets hook that is called right before the process is stopped
+// because of unchecked verification created by Offensive (default: no hook).
+// Use it to flush telemetry or logs, hook is called after warning is written.
+// Pass nil to remove the hook.
+func SetPreExitHook(hook func()) {
+	preExitHook.Store(preExitHookWrapper{hook})
 }
 
 func captureCreationStack() []uintptr {
@@ -199,16 +471,47 @@ func init() {
 	SetUnhandledVerificationsWriter(os.Stdout)
 }
 
+type errFactoryWrapper struct {
+	value func(string, ...interface{}) error
+}
+
+var defaultErrFactory atomic.Value
+
+// SetDefaultErrFactory gives you ability to override error construction function
+// for all verifiers that have no factory set by Verify.WithErrFactory (default: fmt.Errorf wrapping ErrVerificationFailed).
+// Pass nil to restore default.
+func SetDefaultErrFactory(factory func(string, ...interface{}) error) {
+	defaultErrFactory.Store(errFactoryWrapper{factory})
+}
+
 func printWarningOnUncheckedVerification(v *Verify) {
 	if v.checked {
 		return
 	}
+	warning := &bytes.Buffer{}
+	fmt.Fprintf(warning, "[ERROR] found unhandled verification: %s", v)
+	if v.requestID != "" {
+		fmt.Fprintf(warning, ", request ID: %s", v.requestID)
+	}
+	fmt.Fprint(warning, "\n")
+	fmt.Fprint(warning, "verification was created here:\n")
+	v.printCreationStack(warning)
+	if atomic.LoadInt32(&bufferedWarnings) == 1 {
+		queueWarning(v.writer, warning.Bytes())
+		return
+	}
+	writeWarning(v.writer, warning.Bytes())
+}
+
+// writeWarning writes warning to w, or to UnhandledVerificationsWriter if w is nil.
+func writeWarning(w io.Writer, warning []byte) {
+	if w != nil {
+		w.Write(warning)
+		return
+	}
 	rawWriter := verificationsWriter.Load()
 	if rawWriter == nil || rawWriter.(writerWrapper).value == nil {
 		rawWriter = writerWrapper{os.Stdout}
 	}
-	writer := rawWriter.(writerWrapper).value
-	fmt.Fprintf(writer, "[ERROR] found unhandled verification: %s\n", v)
-	fmt.Fprint(writer, "verification was created here:\n")
-	v.printCreationStack(writer)
+	rawWriter.(writerWrapper).value.Write(warning)
 }
@@ -2,6 +2,7 @@ package verifier_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -103,7 +104,7 @@ func TestVerifier_positive_panic_on_error(t *testing.T) {
 		if panicObj == nil {
 			t.Fatal("verifier should have panic")
 		}
-		if panicObj != "verification failure: empty string is not nil" {
+		if fmt.Sprint(panicObj) != "verification failure: empty string is not nil" {
 			t.Errorf("unexpected error message: %s", panicObj)
 		}
 
@@ -172,6 +173,41 @@ func TestVerifier_negative_silent(t *testing.T) {
 	}
 }
 
+func TestVerifier_Scope(t *testing.T) {
+	localBuffer := &safeBuffer{}
+	func() {
+		verify := verifier.New().WithWriter(localBuffer)
+		defer verify.Scope()()
+		verify.That(true, "best effort check")
+		verify.That(false, "another best effort check")
+	}()
+	runtime.GC()
+	time.Sleep(10 * time.Millisecond)
+
+	resultBuffer := localBuffer.String()
+	if len(resultBuffer) != 0 {
+		t.Fatalf("unhandled printed something: %s", resultBuffer)
+	}
+}
+
+func TestVerifier_WithWriter(t *testing.T) {
+	globalBuffer := &safeBuffer{}
+	verifier.SetUnhandledVerificationsWriter(globalBuffer)
+	defer verifier.SetUnhandledVerificationsWriter(os.Stdout)
+	localBuffer := &safeBuffer{}
+
+	verifier.New().WithWriter(localBuffer).That(false, "empty string is not nil")
+	runtime.GC()
+	time.Sleep(10 * time.Millisecond)
+
+	if !strings.HasPrefix(localBuffer.String(), "[ERROR] found unhandled verification: verification failure: empty string is not nil") {
+		t.Fatalf("unexpected verifier buffer: %s", localBuffer)
+	}
+	if len(globalBuffer.String()) != 0 {
+		t.Fatalf("global writer printed something: %s", globalBuffer)
+	}
+}
+
 func TestVerifier_negative_nil_error(t *testing.T) {
 	var verify *verifier.Verify
 	verify.That(len("") != 0, "empty string is not nil")
@@ -231,12 +267,43 @@ func TestVerifies_WithErrorFactory(test *testing.T) {
 			}
 		})
 	}
+	var defaultErr = (&verifier.Verify{}).That(false, "").GetError()
 
-	tf("empty Verifier", &verifier.Verify{}, fmt.Errorf(""))
-	tf("verifier created with New factory", verifier.New(), fmt.Errorf(""))
+	tf("empty Verifier", &verifier.Verify{}, defaultErr)
+	tf("verifier created with New factory", verifier.New(), defaultErr)
 	tf("verifier with TestError factory", verifier.New().WithErrFactory(NewTestError), TestError{})
 }
 
+func TestVerifier_sentinel_error(t *testing.T) {
+	verify := verifier.New()
+	verify.That(false, "age should be 21 or higher, but yours: %d", 18)
+	if !errors.Is(verify.GetError(), verifier.ErrVerificationFailed) {
+		t.Errorf("error should wrap ErrVerificationFailed: %#v", verify.GetError())
+	}
+	if verify.GetError().Error() != "age should be 21 or higher, but yours: 18" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	cause := errors.New("connection refused")
+	verify = verifier.New()
+	verify.That(false, "can't reach database: %w", cause)
+	if !errors.Is(verify.GetError(), verifier.ErrVerificationFailed) {
+		t.Errorf("error should wrap ErrVerificationFailed: %#v", verify.GetError())
+	}
+	if !errors.Is(verify.GetError(), cause) {
+		t.Errorf("error should wrap cause: %#v", verify.GetError())
+	}
+	if verify.GetError().Error() != "can't reach database: connection refused" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New().WithErrFactory(NewTestError)
+	verify.That(false, "custom error")
+	if errors.Is(verify.GetError(), verifier.ErrVerificationFailed) {
+		t.Errorf("custom factory error should be returned as is: %#v", verify.GetError())
+	}
+}
+
 // Testing Offensive verifier, which crashes programm if GCed unchecked.
 // Idea: https://talks.golang.org/2014/testing.slide
 func TestOffensive(test *testing.T) {
@@ -278,3 +345,344 @@ func (s *safeBuffer) String() string {
 	defer s.m.Unlock()
 	return s.b.String()
 }
+
+func TestVerifier_MapError(t *testing.T) {
+	calls := 0
+	toTransportErr := func(err error) error {
+		calls++
+		return fmt.Errorf("bad request: %w", err)
+	}
+
+	verify := verifier.New()
+	verify.That(true, "transfer can't be nil").MapError(toTransportErr)
+	if verify.GetError() != nil {
+		t.Fatal("verifier should be empty")
+	}
+	if calls != 0 {
+		t.Errorf("mapping should not be applied on success")
+	}
+
+	verify.That(false, "transfer amount should be greater than zero").MapError(toTransportErr)
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "bad request: transfer amount should be greater than zero" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+	if calls != 1 {
+		t.Errorf("mapping should be applied once, but was applied %d times", calls)
+	}
+}
+
+func TestVerifier_positive_panic_error(t *testing.T) {
+	verify := verifier.New()
+	expectedErr := errors.New("transfer can't be nil")
+	verify.WithError(false, expectedErr)
+	defer func() {
+		panicObj := recover()
+		panicErr, ok := panicObj.(verifier.PanicError)
+		if !ok {
+			t.Fatalf("unexpected panic type: %T", panicObj)
+		}
+		if panicErr.Err != expectedErr {
+			t.Errorf("unexpected panic error: %s", panicErr.Err)
+		}
+		if !errors.Is(panicErr, expectedErr) {
+			t.Errorf("panic error should wrap verification error")
+		}
+		if panicErr.Error() != "verification failure: transfer can't be nil" {
+			t.Errorf("unexpected error message: %s", panicErr)
+		}
+	}()
+
+	verify.PanicOnError()
+}
+
+func TestVerifier_ErrorWithStack(t *testing.T) {
+	verify := verifier.New()
+	verify.That(true, "transfer can't be nil")
+	err, stack := verify.ErrorWithStack()
+	if err != nil {
+		t.Fatal("verifier should be empty")
+	}
+	if len(stack) == 0 {
+		t.Fatal("creation stack should not be empty")
+	}
+
+	verify.That(false, "transfer amount should be greater than zero")
+	err, stack = verify.ErrorWithStack()
+	if err == nil || err != verify.GetError() {
+		t.Errorf("unexpected error: %v", err)
+	}
+	frame, _ := runtime.CallersFrames(stack).Next()
+	if frame.Function != "github.com/storozhukBM/verifier_test.TestVerifier_ErrorWithStack" {
+		t.Errorf("unexpected creation stack frame: %s", frame.Function)
+	}
+}
+
+func TestVerifier_SetDefaultErrFactory(t *testing.T) {
+	verifier.SetDefaultErrFactory(NewTestError)
+	defer verifier.SetDefaultErrFactory(nil)
+
+	for _, verify := range []*verifier.Verify{verifier.New(), verifier.Offensive(), {}} {
+		err := verify.That(false, "test error message").GetError()
+		if _, ok := err.(TestError); !ok {
+			t.Errorf("got error of invalid type %T", err)
+		}
+	}
+
+	err := verifier.New().WithErrFactory(fmt.Errorf).That(false, "test error message").GetError()
+	if _, ok := err.(TestError); ok {
+		t.Errorf("per-instance error factory should override default")
+	}
+
+	verifier.SetDefaultErrFactory(nil)
+	err = verifier.New().That(false, "test error message").GetError()
+	if !errors.Is(err, verifier.ErrVerificationFailed) {
+		t.Errorf("default error factory should be restored: %#v", err)
+	}
+}
+
+func TestVerifier_WithDetail(t *testing.T) {
+	verify := verifier.New()
+	verify.WithDetail("field", "destination").That(true, "transfer destination can't be empty")
+	verify.WithDetail("field", "amount").WithDetail("min", 1).That(false, "transfer amount should be greater than zero")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "transfer amount should be greater than zero" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+	var detailed *verifier.DetailedError
+	if !errors.As(verify.GetError(), &detailed) {
+		t.Fatalf("error should carry details: %#v", verify.GetError())
+	}
+	expected := map[string]interface{}{"field": "amount", "min": 1}
+	if !reflect.DeepEqual(detailed.Details, expected) {
+		t.Errorf("unexpected details: %v", detailed.Details)
+	}
+	if !errors.Is(verify.GetError(), verifier.ErrVerificationFailed) {
+		t.Errorf("detailed error should wrap original error")
+	}
+}
+
+type contextKey string
+
+func TestVerifier_WithContextFields(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextKey("traceID"), "4bf92f35")
+	ctx = context.WithValue(ctx, contextKey("userID"), 42)
+
+	verify := verifier.New().WithContextFields(ctx, contextKey("traceID"), contextKey("userID"), contextKey("tenant"))
+	verify.That(true, "transfer destination can't be empty")
+	verify.WithDetail("min", 1).That(false, "transfer amount should be greater than zero")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "transfer amount should be greater than zero" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+	var detailed *verifier.DetailedError
+	if !errors.As(verify.GetError(), &detailed) {
+		t.Fatalf("error should carry fields: %#v", verify.GetError())
+	}
+	expected := map[string]interface{}{"traceID": "4bf92f35", "userID": 42, "min": 1}
+	if !reflect.DeepEqual(detailed.Details, expected) {
+		t.Errorf("unexpected details: %v", detailed.Details)
+	}
+}
+
+func TestVerifier_FieldKeys(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextKey("userID"), 42)
+	ctx = context.WithValue(ctx, contextKey("traceID"), "4bf92f35")
+	ctx = context.WithValue(ctx, contextKey("tenant"), "acme")
+
+	verify := verifier.New()
+	if keys := verify.FieldKeys(); keys == nil || len(keys) != 0 {
+		t.Errorf("unexpected keys of empty verifier: %#v", keys)
+	}
+	verify.WithContextFields(ctx, contextKey("userID"), contextKey("traceID"), contextKey("tenant"))
+	expected := []string{"tenant", "traceID", "userID"}
+	if !reflect.DeepEqual(verify.FieldKeys(), expected) {
+		t.Errorf("unexpected keys: %v", verify.FieldKeys())
+	}
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+}
+
+func TestVerifier_WithDetail_reset_after_check(t *testing.T) {
+	verify := verifier.New()
+	verify.WithDetail("field", "destination").That(true, "transfer destination can't be empty")
+	verify.That(false, "transfer amount should be greater than zero")
+	var detailed *verifier.DetailedError
+	if errors.As(verify.GetError(), &detailed) {
+		t.Errorf("details should be discarded after the check: %v", detailed.Details)
+	}
+}
+
+func TestVerifier_SetOffensiveExitFunc(t *testing.T) {
+	localBuffer := &safeBuffer{}
+	verifier.SetUnhandledVerificationsWriter(localBuffer)
+	defer verifier.SetUnhandledVerificationsWriter(os.Stdout)
+	exitCodes := make(chan int, 1)
+	verifier.SetOffensiveExitFunc(func(code int) {
+		exitCodes <- code
+	})
+	defer verifier.SetOffensiveExitFunc(nil)
+
+	verifier.Offensive().That(false, "empty string is not nil")
+	runtime.GC()
+
+	select {
+	case code := <-exitCodes:
+		if code != 1 {
+			t.Errorf("unexpected exit code: %d", code)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("exit func was not called")
+	}
+	if !strings.HasPrefix(localBuffer.String(), "[ERROR] found unhandled verification: verification failure: empty string is not nil") {
+		t.Errorf("unexpected verifier buffer: %s", localBuffer)
+	}
+}
+
+func TestVerifier_SetPreExitHook(t *testing.T) {
+	localBuffer := &safeBuffer{}
+	verifier.SetUnhandledVerificationsWriter(localBuffer)
+	defer verifier.SetUnhandledVerificationsWriter(os.Stdout)
+	events := make(chan string, 2)
+	verifier.SetPreExitHook(func() {
+		events <- "hook"
+	})
+	defer verifier.SetPreExitHook(nil)
+	verifier.SetOffensiveExitFunc(func(code int) {
+		events <- "exit"
+	})
+	defer verifier.SetOffensiveExitFunc(nil)
+
+	verifier.Offensive().That(false, "empty string is not nil")
+	runtime.GC()
+
+	for _, expected := range []string{"hook", "exit"} {
+		select {
+		case event := <-events:
+			if event != expected {
+				t.Errorf("unexpected event: %s, expected: %s", event, expected)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s was not called", expected)
+		}
+	}
+}
+
+func TestVerifier_TotalChecksEvaluated(t *testing.T) {
+	before := verifier.TotalChecksEvaluated()
+
+	first := verifier.New()
+	first.That(true, "transfer can't be nil")
+	first.Predicate(func() bool { return true }, "person can't be nil")
+	if first.GetError() != nil {
+		t.Fatal("verifier should be empty")
+	}
+	second := verifier.New()
+	second.That(false, "transfer amount should be greater than zero")
+	second.That(true, "won't evaluate")
+	verifier.Subset(second, []int{1}, []int{1}, "won't evaluate")
+	if second.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+
+	if evaluated := verifier.TotalChecksEvaluated() - before; evaluated != 3 {
+		t.Errorf("unexpected number of evaluated checks: %d", evaluated)
+	}
+}
+
+func TestVerifier_WithRequestID(t *testing.T) {
+	localBuffer := &safeBuffer{}
+	verifier.SetUnhandledVerificationsWriter(localBuffer)
+	defer verifier.SetUnhandledVerificationsWriter(os.Stdout)
+
+	verify := verifier.New().WithRequestID("req-42")
+	verify.That(len("") != 0, "empty string is not nil")
+	runtime.GC()
+	time.Sleep(10 * time.Millisecond)
+
+	resultBuffer := localBuffer.String()
+	if !strings.HasPrefix(resultBuffer, "[ERROR] found unhandled verification: verification failure: empty string is not nil, request ID: req-42\n") {
+		t.Fatalf("unexpected verifier buffer: %s", resultBuffer)
+	}
+}
+
+func TestVerifier_SetIncludeCallerInMessage(t *testing.T) {
+	verifier.SetIncludeCallerInMessage(true)
+	defer verifier.SetIncludeCallerInMessage(false)
+
+	verify := verifier.New()
+	verify.That(true, "transfer can't be nil")
+	verify.That(false, "transfer amount should be greater than zero")
+	_, _, line, _ := runtime.Caller(0)
+	expected := fmt.Sprintf("transfer amount should be greater than zero (verifier_test.go:%d)", line-1)
+	if verify.GetError() == nil || verify.GetError().Error() != expected {
+		t.Errorf("unexpected error message: %v", verify.GetError())
+	}
+	if !errors.Is(verify.GetError(), verifier.ErrVerificationFailed) {
+		t.Errorf("located error should wrap original error")
+	}
+
+	verify = verifier.New()
+	verifier.Subset(verify, []int{42}, nil, "unknown element")
+	_, _, line, _ = runtime.Caller(0)
+	expected = fmt.Sprintf("unknown element: 42 is missing (verifier_test.go:%d)", line-1)
+	if verify.GetError() == nil || verify.GetError().Error() != expected {
+		t.Errorf("unexpected error message: %v", verify.GetError())
+	}
+}
+
+func TestVerifier_SetStackFilter(t *testing.T) {
+	unhandledWarning := func() string {
+		localBuffer := &safeBuffer{}
+		verifier.SetUnhandledVerificationsWriter(localBuffer)
+		defer verifier.SetUnhandledVerificationsWriter(os.Stdout)
+		verifier.New().That(false, "empty string is not nil")
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+		return localBuffer.String()
+	}
+
+	fullWarning := unhandledWarning()
+	if !strings.Contains(fullWarning, "testing.tRunner") {
+		t.Fatalf("unexpected full warning: %s", fullWarning)
+	}
+
+	verifier.SetStackFilter(func(frame runtime.Frame) bool {
+		return strings.HasPrefix(frame.Function, "github.com/storozhukBM/verifier_test.")
+	})
+	defer verifier.SetStackFilter(nil)
+	filteredWarning := unhandledWarning()
+	if len(filteredWarning) >= len(fullWarning) {
+		t.Errorf("filtered warning should be shorter:\n%s", filteredWarning)
+	}
+	if strings.Contains(filteredWarning, "testing.tRunner") {
+		t.Errorf("filtered warning contains dropped frames:\n%s", filteredWarning)
+	}
+	if !strings.Contains(filteredWarning, "verifier_test.TestVerifier_SetStackFilter") {
+		t.Errorf("filtered warning doesn't contain test frames:\n%s", filteredWarning)
+	}
+}
+
+func TestVerifier_IsValid(t *testing.T) {
+	verify := verifier.New()
+	if !verify.IsValid() {
+		t.Error("verifier created by New should be valid")
+	}
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+	if (&verifier.Verify{}).IsValid() {
+		t.Error("zero verifier should not be valid")
+	}
+	var nilVerify *verifier.Verify
+	if nilVerify.IsValid() {
+		t.Error("nil verifier should not be valid")
+	}
+}
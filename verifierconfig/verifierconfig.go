@@ -0,0 +1,130 @@
+// Package verifierconfig provides declarative struct verification rules on top of verifier package.
+//
+// Rules are loaded from JSON, so they can be defined outside of Go code:
+//
+//	[
+//	  {"field": "Name", "type": "string", "required": true, "max": 64},
+//	  {"field": "Age", "type": "int", "min": 21},
+//	  {"field": "Email", "type": "string", "pattern": "^[^@]+@[^@]+$"}
+//	]
+package verifierconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/storozhukBM/verifier"
+)
+
+// Rule describes verification of single exported struct field.
+//
+// Type is one of "string", "int", "uint", "float" or "bool".
+// Required field should have non-zero value.
+// Min and Max limit value of numbers and length of strings, both inclusive.
+// Pattern is a regular expression string fields should match.
+type Rule struct {
+	Field    string   `json:"field"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Min      *float64 `json:"min"`
+	Max      *float64 `json:"max"`
+	Pattern  string   `json:"pattern"`
+}
+
+var kinds = map[string][]reflect.Kind{
+	"string": {reflect.String},
+	"int":    {reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64},
+	"uint":   {reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64},
+	"float":  {reflect.Float32, reflect.Float64},
+	"bool":   {reflect.Bool},
+}
+
+// Load parses JSON array of rules and returns function verifying struct, or pointer to struct, against them.
+// Rules are applied in order, function returns the first failure.
+func Load(data []byte) (func(obj interface{}) error, error) {
+	var rules []Rule
+	err := json.Unmarshal(data, &rules)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse rules: %v", err)
+	}
+	patterns := make([]*regexp.Regexp, len(rules))
+	for i, rule := range rules {
+		if rule.Field == "" {
+			return nil, fmt.Errorf("rule #%d has no field", i+1)
+		}
+		if _, ok := kinds[rule.Type]; !ok {
+			return nil, fmt.Errorf("rule for %s has unknown type %q", rule.Field, rule.Type)
+		}
+		if rule.Pattern == "" {
+			continue
+		}
+		if rule.Type != "string" {
+			return nil, fmt.Errorf("rule for %s has pattern, but its type is %s", rule.Field, rule.Type)
+		}
+		patterns[i], err = regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("rule for %s has invalid pattern: %v", rule.Field, err)
+		}
+	}
+	return func(obj interface{}) error {
+		verify := verifier.New()
+		value := reflect.ValueOf(obj)
+		if value.Kind() == reflect.Ptr && !value.IsNil() {
+			value = value.Elem()
+		}
+		verify.That(value.Kind() == reflect.Struct, "%T is not a struct", obj)
+		if verify.GetError() != nil {
+			return verify.GetError()
+		}
+		for i, rule := range rules {
+			apply(verify, value, rule, patterns[i])
+		}
+		return verify.GetError()
+	}, nil
+}
+
+func apply(verify *verifier.Verify, obj reflect.Value, rule Rule, pattern *regexp.Regexp) {
+	fieldType, ok := obj.Type().FieldByName(rule.Field)
+	verify.That(ok && fieldType.PkgPath == "", "%s has no exported field %s", obj.Type(), rule.Field)
+	if verify.GetError() != nil {
+		return
+	}
+	field := obj.FieldByIndex(fieldType.Index)
+	verify.That(hasKind(field, kinds[rule.Type]), "%s should be %s, but it's %s", rule.Field, rule.Type, field.Kind())
+	if verify.GetError() != nil {
+		return
+	}
+	verify.That(!rule.Required || !field.IsZero(), "%s is required", rule.Field)
+
+	size, sizeName := 0.0, "value"
+	switch rule.Type {
+	case "string":
+		size, sizeName = float64(field.Len()), "length"
+	case "int":
+		size = float64(field.Int())
+	case "uint":
+		size = float64(field.Uint())
+	case "float":
+		size = field.Float()
+	}
+	if rule.Min != nil {
+		verify.That(size >= *rule.Min, "%s %s should be at least %v, but it's %v", rule.Field, sizeName, *rule.Min, size)
+	}
+	if rule.Max != nil {
+		verify.That(size <= *rule.Max, "%s %s should be at most %v, but it's %v", rule.Field, sizeName, *rule.Max, size)
+	}
+	if pattern != nil {
+		verify.That(pattern.MatchString(field.String()), "%s should match %s", rule.Field, pattern)
+	}
+}
+
+func hasKind(value reflect.Value, kinds []reflect.Kind) bool {
+	for _, k := range kinds {
+		if value.Kind() == k {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package verifierconfig_test
+
+import (
+	"testing"
+
+	"github.com/storozhukBM/verifier/verifierconfig"
+)
+
+const customerRules = `[
+	{"field": "Name", "type": "string", "required": true, "max": 16},
+	{"field": "Age", "type": "int", "min": 21, "max": 150},
+	{"field": "Email", "type": "string", "pattern": "^[^@]+@[^@]+$"},
+	{"field": "HasLicense", "type": "bool", "required": true}
+]`
+
+type customer struct {
+	Name       string
+	Age        int
+	Email      string
+	HasLicense bool
+}
+
+func TestLoad(t *testing.T) {
+	verify, err := verifierconfig.Load([]byte(customerRules))
+	if err != nil {
+		t.Fatalf("can't load rules: %s", err)
+	}
+
+	err = verify(customer{Name: "John Smith", Age: 42, Email: "john@example.com", HasLicense: true})
+	if err != nil {
+		t.Fatalf("customer should be valid: %s", err)
+	}
+
+	testCases := []struct {
+		name     string
+		customer customer
+		message  string
+	}{
+		{"required", customer{Age: 42, Email: "john@example.com", HasLicense: true}, "Name is required"},
+		{"max length", customer{Name: "John Jacob Jingleheimer Schmidt", Age: 42, Email: "john@example.com", HasLicense: true}, "Name length should be at most 16, but it's 31"},
+		{"min value", customer{Name: "John", Age: 18, Email: "john@example.com", HasLicense: true}, "Age value should be at least 21, but it's 18"},
+		{"pattern", customer{Name: "John", Age: 42, Email: "john", HasLicense: true}, "Email should match ^[^@]+@[^@]+$"},
+		{"required bool", customer{Name: "John", Age: 42, Email: "john@example.com"}, "HasLicense is required"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := verify(&testCase.customer)
+			if err == nil {
+				t.Fatal("customer should be invalid")
+			}
+			if err.Error() != testCase.message {
+				t.Errorf("unexpected error message: %s", err)
+			}
+		})
+	}
+}
+
+func TestLoad_mismatched_object(t *testing.T) {
+	verify, err := verifierconfig.Load([]byte(`[{"field": "Age", "type": "int"}]`))
+	if err != nil {
+		t.Fatalf("can't load rules: %s", err)
+	}
+	err = verify(struct{ Age string }{Age: "42"})
+	if err == nil || err.Error() != "Age should be int, but it's string" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = verify(struct{ Name string }{})
+	if err == nil || err.Error() != "struct { Name string } has no exported field Age" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = verify(42)
+	if err == nil || err.Error() != "int is not a struct" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestLoad_invalid_rules(t *testing.T) {
+	testCases := map[string]string{
+		`{"field": "Name"}`:                                     "can't parse rules: json: cannot unmarshal object into Go value of type []verifierconfig.Rule",
+		`[{"type": "string"}]`:                                  "rule #1 has no field",
+		`[{"field": "Name", "type": "text"}]`:                   `rule for Name has unknown type "text"`,
+		`[{"field": "Age", "type": "int", "pattern": "^1"}]`:    "rule for Age has pattern, but its type is int",
+		`[{"field": "Name", "type": "string", "pattern": "("}]`: "rule for Name has invalid pattern: error parsing regexp: missing closing ): `(`",
+	}
+	for rules, message := range testCases {
+		_, err := verifierconfig.Load([]byte(rules))
+		if err == nil {
+			t.Fatalf("rules should be invalid: %s", rules)
+		}
+		if err.Error() != message {
+			t.Errorf("unexpected error message: %s", err)
+		}
+	}
+}
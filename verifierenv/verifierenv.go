@@ -0,0 +1,59 @@
+// Package verifierenv provides verification of environment variables on top of verifier package.
+package verifierenv
+
+import (
+	"os"
+	"strings"
+
+	"github.com/storozhukBM/verifier"
+)
+
+// Lookup retrieves the value of environment variable, reporting whether it's set.
+// os.LookupEnv is used by package level functions,
+// use custom Lookup to verify variables from other sources, e.g. in tests.
+type Lookup func(name string) (string, bool)
+
+// Required verifies that all environment variables are set.
+func Required(v *verifier.Verify, names ...string) *verifier.Verify {
+	return Lookup(os.LookupEnv).Required(v, names...)
+}
+
+// OneOf verifies that environment variable is set to one of allowed values.
+func OneOf(v *verifier.Verify, name string, allowed ...string) *verifier.Verify {
+	return Lookup(os.LookupEnv).OneOf(v, name, allowed...)
+}
+
+// Required verifies that all environment variables are set.
+// On failure, all unset variables are reported at once.
+func (l Lookup) Required(v *verifier.Verify, names ...string) *verifier.Verify {
+	var missing []string
+	for _, name := range names {
+		if _, ok := l(name); !ok {
+			missing = append(missing, name)
+		}
+	}
+	switch len(missing) {
+	case 0:
+		return v.That(true, "environment variables %s are set", strings.Join(names, ", "))
+	case 1:
+		return v.That(false, "environment variable %s is not set", missing[0])
+	default:
+		return v.That(false, "environment variables %s are not set", strings.Join(missing, ", "))
+	}
+}
+
+// OneOf verifies that environment variable is set to one of allowed values.
+func (l Lookup) OneOf(v *verifier.Verify, name string, allowed ...string) *verifier.Verify {
+	value, ok := l(name)
+	v = v.That(ok, "environment variable %s is not set", name)
+	return v.That(contains(allowed, value), "environment variable %s has unsupported value %q, expected one of %q", name, value, allowed)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package verifierenv_test
+
+import (
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+	"github.com/storozhukBM/verifier/verifierenv"
+)
+
+var fakeEnv = verifierenv.Lookup(func(name string) (string, bool) {
+	value, ok := map[string]string{
+		"DATABASE_URL": "postgres://localhost/db",
+		"LOG_LEVEL":    "verbose",
+		"MODE":         "production",
+	}[name]
+	return value, ok
+})
+
+func TestRequired(t *testing.T) {
+	verify := verifier.New()
+	fakeEnv.Required(verify, "DATABASE_URL", "MODE")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	fakeEnv.Required(verify, "DATABASE_URL", "API_KEY", "SECRET")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "environment variables API_KEY, SECRET are not set" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	fakeEnv.Required(verify, "DATABASE_URL", "API_KEY")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "environment variable API_KEY is not set" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestOneOf(t *testing.T) {
+	verify := verifier.New()
+	fakeEnv.OneOf(verify, "MODE", "development", "production")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	fakeEnv.OneOf(verify, "LOG_LEVEL", "debug", "info")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	expected := `environment variable LOG_LEVEL has unsupported value "verbose", expected one of ["debug" "info"]`
+	if verify.GetError().Error() != expected {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+
+	verify = verifier.New()
+	fakeEnv.OneOf(verify, "REGION", "eu", "us")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "environment variable REGION is not set" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestRequired_os_environment(t *testing.T) {
+	t.Setenv("VERIFIERENV_TEST_VARIABLE", "set")
+	verify := verifier.New()
+	verifierenv.Required(verify, "VERIFIERENV_TEST_VARIABLE")
+	verifierenv.OneOf(verify, "VERIFIERENV_TEST_VARIABLE", "set")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+}
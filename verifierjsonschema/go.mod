@@ -0,0 +1,10 @@
+module github.com/storozhukBM/verifier/verifierjsonschema
+
+go 1.18
+
+require (
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1
+	github.com/storozhukBM/verifier v0.0.0
+)
+
+replace github.com/storozhukBM/verifier => ../
@@ -0,0 +1,96 @@
+// Package verifierjsonschema provides JSON Schema validation on top of verifier package.
+// It is a separate module, so verifier module itself stays free of third-party dependencies.
+package verifierjsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/storozhukBM/verifier"
+	"github.com/storozhukBM/verifier/internal/explain"
+)
+
+// Validator validates JSON document against JSON Schema.
+// It returns description of every schema violation found in document
+// and non-nil error only if document or schema can't be processed at all.
+type Validator interface {
+	Validate(document []byte, schema []byte) ([]string, error)
+}
+
+// Valid verifies that JSON document conforms to JSON Schema,
+// using default validator backed by github.com/santhosh-tekuri/jsonschema.
+// See ValidWith for details.
+func Valid(v *verifier.Verify, document []byte, schema []byte, message string, args ...interface{}) *verifier.Verify {
+	return ValidWith(v, defaultValidator{}, document, schema, message, args...)
+}
+
+// ValidWith verifies that JSON document conforms to JSON Schema, using provided validator.
+// On failure, message is suffixed with all found violations, so each of them is reported.
+// Invalid document or schema are reported as failures as well.
+// After the first failed verification validation won't be performed.
+func ValidWith(v *verifier.Verify, validator Validator, document []byte, schema []byte, message string, args ...interface{}) *verifier.Verify {
+	return explain.Check(v, func() string {
+		violations, err := validator.Validate(document, schema)
+		if err != nil {
+			return err.Error()
+		}
+		return strings.Join(violations, "; ")
+	}, message, args...)
+}
+
+type defaultValidator struct{}
+
+func (defaultValidator) Validate(document []byte, schema []byte) ([]string, error) {
+	const schemaURL = "schema.json"
+	compiler := jsonschema.NewCompiler()
+	err := compiler.AddResource(schemaURL, bytes.NewReader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema: %v", err)
+	}
+	compiled, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema: %v", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	var instance interface{}
+	err = decoder.Decode(&instance)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document: %v", err)
+	}
+
+	err = compiled.Validate(instance)
+	if err == nil {
+		return nil, nil
+	}
+	validationErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return nil, err
+	}
+	var violations []string
+	collectViolations(validationErr, &violations)
+	sort.Strings(violations)
+	return violations, nil
+}
+
+func collectViolations(err *jsonschema.ValidationError, violations *[]string) {
+	if len(err.Causes) == 0 {
+		*violations = append(*violations, fmt.Sprintf("%s: %s", instanceLocation(err), err.Message))
+		return
+	}
+	for _, cause := range err.Causes {
+		collectViolations(cause, violations)
+	}
+}
+
+func instanceLocation(err *jsonschema.ValidationError) string {
+	if err.InstanceLocation == "" {
+		return "/"
+	}
+	return err.InstanceLocation
+}
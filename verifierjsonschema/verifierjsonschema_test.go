@@ -0,0 +1,87 @@
+package verifierjsonschema_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+	"github.com/storozhukBM/verifier/verifierjsonschema"
+)
+
+const personSchema = `{
+	"type": "object",
+	"properties": {
+		"name": {"type": "string", "minLength": 1},
+		"age": {"type": "integer", "minimum": 21}
+	},
+	"required": ["name", "age"]
+}`
+
+func TestValid_positive(t *testing.T) {
+	verify := verifier.New()
+	verifierjsonschema.Valid(verify, []byte(`{"name": "John", "age": 42}`), []byte(personSchema), "invalid person")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+}
+
+func TestValid_record(t *testing.T) {
+	verify := verifier.NewRecording()
+	verifierjsonschema.Valid(verify, []byte(`{"name": "John", "age": 42}`), []byte(personSchema), "invalid %s", "person")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+	record := verify.Record()
+	if len(record) != 1 || record[0] != (verifier.CheckResult{Message: "invalid person", Passed: true}) {
+		t.Errorf("unexpected record: %v", record)
+	}
+}
+
+func TestValid_negative_multiple_violations(t *testing.T) {
+	verify := verifier.New()
+	verifierjsonschema.Valid(verify, []byte(`{"name": "", "age": 18}`), []byte(personSchema), "invalid %s", "person")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	message := verify.GetError().Error()
+	if !strings.HasPrefix(message, "invalid person: ") {
+		t.Errorf("unexpected error message: %s", message)
+	}
+	if !strings.Contains(message, "/age") || !strings.Contains(message, "/name") {
+		t.Errorf("error message should contain all violations: %s", message)
+	}
+}
+
+func TestValid_negative_invalid_document(t *testing.T) {
+	verify := verifier.New()
+	verifierjsonschema.Valid(verify, []byte(`{"name": `), []byte(personSchema), "invalid person")
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if !strings.Contains(verify.GetError().Error(), "invalid document") {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+type fakeValidator struct {
+	calls int
+}
+
+func (f *fakeValidator) Validate(document []byte, schema []byte) ([]string, error) {
+	f.calls++
+	return nil, errors.New("should not be called")
+}
+
+func TestValidWith_not_evaluate_after_failure(t *testing.T) {
+	validator := &fakeValidator{}
+	verify := verifier.New()
+	verify.That(false, "first failure")
+	verifierjsonschema.ValidWith(verify, validator, nil, nil, "invalid document")
+	if verify.GetError().Error() != "first failure" {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+	if validator.calls != 0 {
+		t.Errorf("unexpected evaluations happened")
+	}
+}
@@ -0,0 +1,42 @@
+// Package verifiernet provides verification of network addresses on top of verifier package.
+package verifiernet
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/storozhukBM/verifier"
+	"github.com/storozhukBM/verifier/internal/explain"
+)
+
+// IPInCIDRs verifies that IP address ip falls within one of CIDR ranges cidrs, e.g. to check client IP against allowlist.
+// Both IPv4 and IPv6 addresses and ranges are supported.
+// On failure, message is followed by the address, or by description of invalid address or range.
+// After the first failed verification ranges won't be parsed.
+func IPInCIDRs(v *verifier.Verify, ip string, cidrs []string, message string, args ...interface{}) *verifier.Verify {
+	return explain.Check(v, func() string {
+		return ipInCIDRs(ip, cidrs)
+	}, message, args...)
+}
+
+// ipInCIDRs returns description of the reason why ip doesn't fall within cidrs, or empty string if it does.
+func ipInCIDRs(ip string, cidrs []string) string {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return fmt.Sprintf("invalid IP address %q", ip)
+	}
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return fmt.Sprintf("invalid CIDR %q", cidr)
+		}
+		networks = append(networks, network)
+	}
+	for _, network := range networks {
+		if network.Contains(parsedIP) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%s is not in allowed ranges", ip)
+}
@@ -0,0 +1,52 @@
+package verifiernet_test
+
+import (
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+	"github.com/storozhukBM/verifier/verifiernet"
+)
+
+func TestIPInCIDRs(t *testing.T) {
+	allowlist := []string{"10.0.0.0/8", "192.168.1.0/24", "2001:db8::/32"}
+	verify := verifier.New()
+	verifiernet.IPInCIDRs(verify, "10.20.30.40", allowlist, "client is not allowed")
+	verifiernet.IPInCIDRs(verify, "192.168.1.255", allowlist, "client is not allowed")
+	verifiernet.IPInCIDRs(verify, "2001:db8::1", allowlist, "client is not allowed")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	testCases := []struct {
+		ip      string
+		cidrs   []string
+		message string
+	}{
+		{"192.168.2.1", allowlist, "client is not allowed: 192.168.2.1 is not in allowed ranges"},
+		{"10.0.0.1", nil, "client is not allowed: 10.0.0.1 is not in allowed ranges"},
+		{"10.0.0.256", allowlist, `client is not allowed: invalid IP address "10.0.0.256"`},
+		{"10.0.0.1", []string{"10.0.0.0/8", "192.168.1.0/33"}, `client is not allowed: invalid CIDR "192.168.1.0/33"`},
+	}
+	for _, testCase := range testCases {
+		verify := verifier.New()
+		verifiernet.IPInCIDRs(verify, testCase.ip, testCase.cidrs, "client is not %s", "allowed")
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %s", testCase.ip)
+		}
+		if verify.GetError().Error() != testCase.message {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+}
+
+func TestIPInCIDRs_record(t *testing.T) {
+	verify := verifier.NewRecording()
+	verifiernet.IPInCIDRs(verify, "10.20.30.40", []string{"10.0.0.0/8"}, "client %s is not allowed", "gateway")
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+	record := verify.Record()
+	if len(record) != 1 || record[0] != (verifier.CheckResult{Message: "client gateway is not allowed", Passed: true}) {
+		t.Errorf("unexpected record: %v", record)
+	}
+}
@@ -0,0 +1,119 @@
+// Package verifiersnapshot provides verification of values against recorded snapshots on top of verifier package.
+//
+// Snapshots are test-only feature that reads files and environment,
+// so they are kept out of verifier package and its Verify type.
+// Methods can't be declared on Verify outside of verifier package,
+// so instead of Verify.EqualsSnapshot(name, actual) snapshots are verified using
+// Equals(v, name, actual), or Dir(dir).Equals(v, name, actual) for snapshots stored outside of DefaultDir.
+package verifiersnapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/storozhukBM/verifier"
+	"github.com/storozhukBM/verifier/internal/explain"
+)
+
+// DefaultDir is directory, where snapshots are stored by package level functions.
+const DefaultDir = Dir("testdata/snapshots")
+
+// Dir is directory, where snapshots are stored.
+// Use custom Dir to store snapshots outside of DefaultDir, e.g. in temporary directory in tests.
+type Dir string
+
+// Equals verifies that actual, encoded as indented JSON, is equal to previously recorded snapshot with the name,
+// stored in DefaultDir.
+func Equals(v *verifier.Verify, name string, actual interface{}) *verifier.Verify {
+	return DefaultDir.Equals(v, name, actual)
+}
+
+// Equals verifies that actual, encoded as indented JSON, is equal to previously recorded snapshot with the name.
+// Snapshot is stored in the file <name>.json in the directory, so name can't contain path separators or "..".
+// If there is no such snapshot, or UPDATE_SNAPSHOTS environment variable is set to true,
+// actual is recorded as snapshot and check passes.
+// On failure, error message contains lines removed from snapshot, prefixed with "-",
+// and lines added in actual, prefixed with "+".
+func (d Dir) Equals(v *verifier.Verify, name string, actual interface{}) *verifier.Verify {
+	return explain.Check(v, func() string {
+		return d.compare(name, actual)
+	}, "snapshot %s", name)
+}
+
+// compare returns description of the reason why actual doesn't match snapshot, or empty string if it does.
+func (d Dir) compare(name string, actual interface{}) string {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") {
+		return `invalid name, it can't be empty or contain path separators or ".."`
+	}
+	encoded, err := json.MarshalIndent(actual, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("can't encode %T: %v", actual, err)
+	}
+	encoded = append(encoded, '\n')
+	path := filepath.Join(string(d), name+".json")
+	stored, err := os.ReadFile(path)
+	update, _ := strconv.ParseBool(os.Getenv("UPDATE_SNAPSHOTS"))
+	if errors.Is(err, os.ErrNotExist) || update {
+		err = os.MkdirAll(string(d), 0o755)
+		if err == nil {
+			err = os.WriteFile(path, encoded, 0o644)
+		}
+		if err != nil {
+			return fmt.Sprintf("can't record: %v", err)
+		}
+		return ""
+	}
+	if err != nil {
+		return fmt.Sprintf("can't read: %v", err)
+	}
+	if bytes.Equal(stored, encoded) {
+		return ""
+	}
+	return "differs:\n" + lineDiff(string(stored), string(encoded))
+}
+
+// lineDiff returns lines removed from expected, prefixed with "-", and lines added in actual, prefixed with "+",
+// based on the longest common subsequence of their lines. Common lines are omitted.
+func lineDiff(expected, actual string) string {
+	expectedLines := strings.Split(strings.TrimSuffix(expected, "\n"), "\n")
+	actualLines := strings.Split(strings.TrimSuffix(actual, "\n"), "\n")
+	// common[i][j] is length of the longest common subsequence of expectedLines[i:] and actualLines[j:]
+	common := make([][]int, len(expectedLines)+1)
+	for i := range common {
+		common[i] = make([]int, len(actualLines)+1)
+	}
+	for i := len(expectedLines) - 1; i >= 0; i-- {
+		for j := len(actualLines) - 1; j >= 0; j-- {
+			switch {
+			case expectedLines[i] == actualLines[j]:
+				common[i][j] = common[i+1][j+1] + 1
+			case common[i+1][j] >= common[i][j+1]:
+				common[i][j] = common[i+1][j]
+			default:
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+	diff := &strings.Builder{}
+	i, j := 0, 0
+	for i < len(expectedLines) || j < len(actualLines) {
+		switch {
+		case i < len(expectedLines) && j < len(actualLines) && expectedLines[i] == actualLines[j]:
+			i++
+			j++
+		case j == len(actualLines) || (i < len(expectedLines) && common[i+1][j] >= common[i][j+1]):
+			fmt.Fprintf(diff, "-%s\n", expectedLines[i])
+			i++
+		default:
+			fmt.Fprintf(diff, "+%s\n", actualLines[j])
+			j++
+		}
+	}
+	return strings.TrimSuffix(diff.String(), "\n")
+}
@@ -0,0 +1,110 @@
+package verifiersnapshot_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/storozhukBM/verifier"
+	"github.com/storozhukBM/verifier/verifiersnapshot"
+)
+
+type order struct {
+	ID     int      `json:"id"`
+	Status string   `json:"status"`
+	Items  []string `json:"items"`
+}
+
+func TestEquals(t *testing.T) {
+	t.Setenv("UPDATE_SNAPSHOTS", "")
+	dir := t.TempDir()
+	snapshots := verifiersnapshot.Dir(dir)
+	o := order{ID: 42, Status: "paid", Items: []string{"book", "pen"}}
+
+	verify := snapshots.Equals(verifier.New(), "order", o)
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+	stored, err := os.ReadFile(filepath.Join(dir, "order.json"))
+	if err != nil {
+		t.Fatalf("snapshot should be recorded: %s", err)
+	}
+	expected := "{\n  \"id\": 42,\n  \"status\": \"paid\",\n  \"items\": [\n    \"book\",\n    \"pen\"\n  ]\n}\n"
+	if string(stored) != expected {
+		t.Errorf("unexpected snapshot: %s", stored)
+	}
+
+	snapshots.Equals(verify, "order", o)
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	o.Status = "refunded"
+	snapshots.Equals(verify, "order", o)
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "snapshot order: differs:\n-  \"status\": \"paid\",\n+  \"status\": \"refunded\"," {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestEquals_inserted_line(t *testing.T) {
+	t.Setenv("UPDATE_SNAPSHOTS", "")
+	snapshots := verifiersnapshot.Dir(t.TempDir())
+	o := order{ID: 42, Status: "paid", Items: []string{"book", "pen"}}
+	snapshots.Equals(verifier.New(), "order", o).GetError()
+
+	o.Items = []string{"notebook", "book", "pen"}
+	verify := snapshots.Equals(verifier.New(), "order", o)
+	if verify.GetError() == nil {
+		t.Fatal("verifier should be filled")
+	}
+	if verify.GetError().Error() != "snapshot order: differs:\n+    \"notebook\"," {
+		t.Errorf("unexpected error message: %s", verify.GetError())
+	}
+}
+
+func TestEquals_update(t *testing.T) {
+	snapshots := verifiersnapshot.Dir(t.TempDir())
+	o := order{ID: 42, Status: "paid"}
+	verify := snapshots.Equals(verifier.New(), "order", o)
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	t.Setenv("UPDATE_SNAPSHOTS", "true")
+	o.Status = "refunded"
+	snapshots.Equals(verify, "order", o)
+	if verify.GetError() != nil {
+		t.Fatalf("verifier should be empty: %s", verify.GetError())
+	}
+
+	t.Setenv("UPDATE_SNAPSHOTS", "")
+	snapshots.Equals(verify, "order", o)
+	if verify.GetError() != nil {
+		t.Fatalf("snapshot should be updated: %s", verify.GetError())
+	}
+}
+
+func TestEquals_invalid_name(t *testing.T) {
+	dir := t.TempDir()
+	snapshots := verifiersnapshot.Dir(filepath.Join(dir, "snapshots"))
+	for _, name := range []string{"", "../order", "orders/paid", `orders\paid`, ".."} {
+		verify := snapshots.Equals(verifier.New(), name, order{ID: 42})
+		if verify.GetError() == nil {
+			t.Fatalf("verifier should be filled for %q", name)
+		}
+		expected := "snapshot " + name + `: invalid name, it can't be empty or contain path separators or ".."`
+		if verify.GetError().Error() != expected {
+			t.Errorf("unexpected error message: %s", verify.GetError())
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("can't read directory: %s", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("nothing should be recorded: %v", entries)
+	}
+}
@@ -0,0 +1,70 @@
+package verifier
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+var bufferedWarnings int32
+
+// SetBufferedWarnings enables or disables buffered mode for unhandled verification warnings (default: disabled).
+// In buffered mode warnings are queued and written to UnhandledVerificationsWriter by single goroutine,
+// so output is serialized and decoupled from GC finalizers.
+// If the queue is full, warning is written directly by the finalizer goroutine instead of waiting for the queue,
+// so such warnings may be written out of order.
+// Use FlushWarnings to make sure all queued warnings are written, e.g. on shutdown.
+func SetBufferedWarnings(enabled bool) {
+	value := int32(0)
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&bufferedWarnings, value)
+}
+
+// FlushWarnings blocks until all warnings queued in buffered mode are written.
+func FlushWarnings() {
+	if atomic.LoadInt32(&warningsWriterStarted) == 0 {
+		return
+	}
+	flushed := make(chan struct{})
+	warningsQueue <- queuedWarning{flushed: flushed}
+	<-flushed
+}
+
+// queuedWarning is either warning to write or flush request, signaled after all previous warnings are written.
+type queuedWarning struct {
+	writer  io.Writer
+	warning []byte
+	flushed chan struct{}
+}
+
+var (
+	warningsQueue         = make(chan queuedWarning, 1024)
+	warningsWriterOnce    sync.Once
+	warningsWriterStarted int32
+)
+
+func queueWarning(w io.Writer, warning []byte) {
+	warningsWriterOnce.Do(func() {
+		// flag is set before the writer starts, so FlushWarnings never skips queued warnings
+		atomic.StoreInt32(&warningsWriterStarted, 1)
+		go writeQueuedWarnings()
+	})
+	select {
+	case warningsQueue <- queuedWarning{writer: w, warning: warning}:
+	default:
+		// queue is full, write directly instead of blocking finalizer goroutine
+		writeWarning(w, warning)
+	}
+}
+
+func writeQueuedWarnings() {
+	for w := range warningsQueue {
+		if w.flushed != nil {
+			close(w.flushed)
+			continue
+		}
+		writeWarning(w.writer, w.warning)
+	}
+}
@@ -0,0 +1,81 @@
+package verifier_test
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/storozhukBM/verifier"
+)
+
+// writesRecorder keeps every write separately, so it can be checked that each warning is written at once.
+type writesRecorder struct {
+	m      sync.Mutex
+	writes []string
+}
+
+func (r *writesRecorder) Write(p []byte) (int, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.writes = append(r.writes, string(p))
+	return len(p), nil
+}
+
+func (r *writesRecorder) Writes() []string {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return append([]string(nil), r.writes...)
+}
+
+func TestVerifier_SetBufferedWarnings(t *testing.T) {
+	recorder := &writesRecorder{}
+	verifier.SetUnhandledVerificationsWriter(recorder)
+	defer verifier.SetUnhandledVerificationsWriter(os.Stdout)
+	verifier.SetBufferedWarnings(true)
+	defer verifier.SetBufferedWarnings(false)
+
+	const count = 100
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			verifier.New().That(false, "unhandled failure #%d", i)
+		}(i)
+	}
+	wg.Wait()
+
+	const prefix = "[ERROR] found unhandled verification: verification failure: unhandled failure #"
+	ownWarnings := func() []string {
+		// warnings of other tests' verifiers, finalized meanwhile, are ignored
+		var result []string
+		for _, w := range recorder.Writes() {
+			if strings.HasPrefix(w, prefix) {
+				result = append(result, w)
+			}
+		}
+		return result
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(ownWarnings()) < count && time.Now().Before(deadline) {
+		runtime.GC()
+		verifier.FlushWarnings()
+	}
+
+	writes := ownWarnings()
+	if len(writes) != count {
+		t.Fatalf("unexpected number of warnings: %d", len(writes))
+	}
+	for _, w := range writes {
+		if strings.Count(w, "[ERROR]") != 1 || !strings.HasSuffix(w, "\n") {
+			t.Fatalf("warning is not written at once: %s", w)
+		}
+	}
+}
+
+func TestVerifier_FlushWarnings_without_buffering(t *testing.T) {
+	verifier.FlushWarnings()
+}